@@ -43,8 +43,12 @@ func main() {
 	tviewApp := tview.NewApplication()
 	router := ui.NewRouter(tviewApp)
 
+	// Client of the currently selected instance, closed when switching instances or quitting
+	var activeClient *argocd.ArgoCdClient
+
 	switchToInstance := func(inst *config.Instance) {
 		instanceInfo := common.NewInstanceInfo(inst.Url, inst.Name)
+		instanceInfo.Production = inst.Production
 		noAuthClient := argocd.NewArgoCdClient(inst, logger, ctx)
 
 		authHandler := auth.NewAuth(instanceInfo.Name, inst, noAuthClient, logger, ctx)
@@ -55,6 +59,7 @@ func main() {
 		// get token, fetch apps and render the list
 		go func() {
 			token, err := authHandler.GetToken()
+			noAuthClient.Close()
 			if err != nil {
 				logger.Errorf("Error getting auth token: %v", err)
 				// this cannot continue. close the app
@@ -64,7 +69,11 @@ func main() {
 			inst.Token = token
 
 			tviewApp.QueueUpdateDraw(func() {
+				if activeClient != nil {
+					activeClient.Close()
+				}
 				argocdClient := argocd.NewArgoCdClient(inst, logger, ctx)
+				activeClient = argocdClient
 				apps, err := argocdClient.GetApps()
 				if err != nil {
 					logger.Errorf("Error getting all applications: %v", err)
@@ -72,7 +81,7 @@ func main() {
 				}
 
 				appList := applicationlist.New(tviewApp, argocdClient, router, instanceInfo, apps)
-				router.AddScreen(appList)
+				router.ReplaceScreen(appList)
 				router.SwitchTo(appList.Name())
 			})
 		}()
@@ -98,5 +107,8 @@ func main() {
 	}
 	logger.Info("Closing application")
 	tviewApp.Stop()
+	if activeClient != nil {
+		activeClient.Close()
+	}
 	cancel()
 }
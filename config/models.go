@@ -6,6 +6,10 @@ type Instance struct {
 	Token              string    `mapstructure:"token"`
 	LoginType          LoginType `mapstructure:"logintype"`
 	InsecureSkipVerify bool      `mapstructure:"insecureskipverify"`
+	// Production asks for the app name to be typed before the app is deleted.
+	// Other actions, such as resource deletion or a sync with prune, are not
+	// confirmed by name
+	Production bool `mapstructure:"production"`
 }
 
 type LoginType string
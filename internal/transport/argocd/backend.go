@@ -0,0 +1,47 @@
+package argocd
+
+import (
+	"context"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+)
+
+// Backend is the part of the ArgoCD API the UI screens depend on.
+// ArgoCdClient talks to a real server; the fake package provides an
+// in-memory implementation for running the screens offline.
+type Backend interface {
+	GetApps() ([]Application, error)
+	GetApp(appName string) (Application, error)
+	GetAppSpec(appName string) (AppSpec, error)
+	UpdateAppSpec(appName string, spec AppSpec) error
+	GetAppParameters(appName string) (AppParameters, error)
+	SetSyncPolicy(appName string, policy SyncPolicy) error
+	GetHistory(appName string) ([]HistoryEntry, error)
+	GetRevisionMetadata(appName, revision string) (*RevisionMetadata, error)
+	Rollback(appName string, id int64, prune bool) error
+	TerminateOperation(appName string) error
+	GetAppResources(appName string) ([]Resource, error)
+	GetResourceTree(appName string) (*v1alpha1.ApplicationTree, error)
+	GetResourceDiffs(appName string) ([]ResourceDiff, error)
+	GetResourceStatuses(appName string) ([]ResourceStatus, error)
+	GetResourceManifest(appName string, ref ResourceRef) (string, error)
+	RefreshApp(appName string, refreshType string) error
+	SyncApp(appName string, opts SyncOptions) error
+	CreateApp(manifest string) error
+	DeleteApp(appName string, opts DeleteOptions) error
+	ListProjects() ([]Project, error)
+	ListClusters() ([]Cluster, error)
+	ListRepositories() ([]Repository, error)
+	ListEvents(appName string, ref *ResourceRef) ([]Event, error)
+	ListResourceActions(appName string, ref ResourceRef) ([]ResourceAction, error)
+	RunResourceAction(appName string, ref ResourceRef, action string) error
+	DeleteResource(appName string, ref ResourceRef, opts DeleteResourceOptions) error
+	GetContainers(appName string, ref ResourceRef) ([]string, error)
+	StreamPodLogs(ctx context.Context, appName string, ref ResourceRef, opts LogOptions, onLine func(LogLine)) error
+
+	WatchApps(ctx context.Context, onEvent func(AppEvent), onStatus WatchStatusHandler)
+	WatchApp(ctx context.Context, appName string, onApp func(Application), onStatus WatchStatusHandler)
+	WatchResourceTree(ctx context.Context, appName string, onTree func(*v1alpha1.ApplicationTree), onStatus WatchStatusHandler)
+}
+
+var _ Backend = (*ArgoCdClient)(nil)
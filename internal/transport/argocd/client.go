@@ -2,18 +2,28 @@ package argocd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/Jack200062/ArguTUI/config"
 	"github.com/Jack200062/ArguTUI/pkg/logging"
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/cluster"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/project"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/repository"
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/session"
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/settings"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/version"
 	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 	"github.com/coreos/go-oidc/v3/oidc"
 	"golang.org/x/oauth2"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
 )
 
 type ArgoCdClient struct {
@@ -21,6 +31,32 @@ type ArgoCdClient struct {
 	client apiclient.Client
 	logger *logging.Logger
 	ctx    context.Context
+
+	// gRPC connections are reused across calls and closed by Close
+	appConn      *cachedConn[application.ApplicationServiceClient]
+	settingsConn *cachedConn[settings.SettingsServiceClient]
+	sessionConn  *cachedConn[session.SessionServiceClient]
+	repoConn     *cachedConn[repository.RepositoryServiceClient]
+	projectConn  *cachedConn[project.ProjectServiceClient]
+	clusterConn  *cachedConn[cluster.ClusterServiceClient]
+	versionConn  *cachedConn[version.VersionServiceClient]
+
+	// stopHealthCheck ends the checkHealth goroutine
+	stopHealthCheck context.CancelFunc
+
+	// managed caches ManagedResources per application for managedResourcesTTL,
+	// as the resources, diffs and statuses are all derived from it
+	managedMu sync.Mutex
+	managed   map[string]managedResult
+}
+
+// managedResourcesTTL is how long a ManagedResources response is reused. It
+// only needs to cover the calls made for a single tree update or screen.
+const managedResourcesTTL = 2 * time.Second
+
+type managedResult struct {
+	resList   *application.ManagedResourcesResponse
+	fetchedAt time.Time
 }
 
 func (a *ArgoCdClient) HttpClient() (*http.Client, error) {
@@ -37,82 +73,333 @@ func NewArgoCdClient(cfg *config.Instance, l *logging.Logger, ctx context.Contex
 	if err != nil {
 		l.Fatal("Error creating ArgoCD client: %v", err)
 	}
-	return &ArgoCdClient{
-		cfg:    cfg,
-		client: c,
-		logger: l,
-		ctx:    ctx,
+	a := &ArgoCdClient{
+		cfg:          cfg,
+		client:       c,
+		logger:       l,
+		ctx:          ctx,
+		appConn:      newCachedConn(c.NewApplicationClient),
+		settingsConn: newCachedConn(c.NewSettingsClient),
+		sessionConn:  newCachedConn(c.NewSessionClient),
+		repoConn:     newCachedConn(c.NewRepoClient),
+		projectConn:  newCachedConn(c.NewProjectClient),
+		clusterConn:  newCachedConn(c.NewClusterClient),
+		versionConn:  newCachedConn(c.NewVersionClient),
 	}
+	healthCtx, stop := context.WithCancel(ctx)
+	a.stopHealthCheck = stop
+	go a.checkHealth(healthCtx)
+	return a
 }
 
-// TODO: cache clients returned bu New...Client() calls to reuse GRPC connections
+// cachedConnection is a cachedConn of any service.
+type cachedConnection interface {
+	drop()
+	close() error
+}
 
-func (a *ArgoCdClient) GetApps() ([]Application, error) {
-	closer, appClient, err := a.client.NewApplicationClient()
-	if err != nil {
-		return nil, a.logger.Errorf("Error creating argocd client: %v", err)
+// unaryConns returns the connections shared by unary calls.
+func (a *ArgoCdClient) unaryConns() []cachedConnection {
+	return []cachedConnection{
+		a.appConn, a.settingsConn, a.sessionConn, a.repoConn, a.projectConn, a.clusterConn, a.versionConn,
+	}
+}
+
+// Close stops the health check and shuts down all cached connections. The
+// client cannot be used afterwards.
+func (a *ArgoCdClient) Close() error {
+	a.stopHealthCheck()
+	var firstErr error
+	for _, c := range a.unaryConns() {
+		if err := c.close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
 	}
-	defer closer.Close()
-	appList, err := appClient.List(a.ctx, &application.ApplicationQuery{})
+	return firstErr
+}
+
+// withAppClient runs a read, retried once on a broken connection.
+func (a *ArgoCdClient) withAppClient(fn func(application.ApplicationServiceClient) error) error {
+	return a.appConn.call(fn)
+}
+
+// writeWithAppClient runs a change to an application. It is never retried, so
+// a sync or delete that reached the server before the connection broke is not
+// sent twice.
+func (a *ArgoCdClient) writeWithAppClient(fn func(application.ApplicationServiceClient) error) error {
+	return a.appConn.callOnce(fn)
+}
+
+func (a *ArgoCdClient) GetApps() ([]Application, error) {
+	var appList *v1alpha1.ApplicationList
+	err := a.withAppClient(func(appClient application.ApplicationServiceClient) (err error) {
+		appList, err = appClient.List(a.ctx, &application.ApplicationQuery{})
+		return err
+	})
 	if err != nil {
 		return nil, a.logger.Errorf("Error getting application list: %v", err)
 	}
 
 	var apps []Application
-	for _, app := range appList.Items {
-		var lastSyncTime string
-
-		if !app.Status.ReconciledAt.IsZero() {
-			lastSyncTime = app.Status.ReconciledAt.Format("2006-01-02 15:04:05")
-		} else if app.Status.OperationState != nil && !app.Status.OperationState.FinishedAt.IsZero() {
-			lastSyncTime = app.Status.OperationState.FinishedAt.Format("2006-01-02 15:04:05")
-		} else if !app.CreationTimestamp.IsZero() {
-			lastSyncTime = app.CreationTimestamp.Format("2006-01-02 15:04:05")
-		} else {
-			lastSyncTime = "n/a"
+	for i := range appList.Items {
+		apps = append(apps, toApplication(&appList.Items[i]))
+	}
+	return apps, nil
+}
+
+func (a *ArgoCdClient) GetApp(appName string) (Application, error) {
+	var app *v1alpha1.Application
+	err := a.withAppClient(func(appClient application.ApplicationServiceClient) (err error) {
+		app, err = appClient.Get(a.ctx, &application.ApplicationQuery{Name: &appName})
+		return err
+	})
+	if err != nil {
+		return Application{}, a.logger.Errorf("Error getting application %s: %v", appName, err)
+	}
+	return toApplication(app), nil
+}
+
+// TerminateOperation stops the sync operation currently running for the application.
+func (a *ArgoCdClient) TerminateOperation(appName string) error {
+	err := a.writeWithAppClient(func(appClient application.ApplicationServiceClient) error {
+		_, err := appClient.TerminateOperation(a.ctx, &application.OperationTerminateRequest{Name: &appName})
+		return err
+	})
+	if err != nil {
+		return a.logger.Errorf("Error terminating operation of %s: %v", appName, err)
+	}
+	return nil
+}
+
+// GetHistory returns the deployment history of the application, oldest first.
+func (a *ArgoCdClient) GetHistory(appName string) ([]HistoryEntry, error) {
+	var app *v1alpha1.Application
+	err := a.withAppClient(func(appClient application.ApplicationServiceClient) (err error) {
+		app, err = appClient.Get(a.ctx, &application.ApplicationQuery{Name: &appName})
+		return err
+	})
+	if err != nil {
+		return nil, a.logger.Errorf("Error getting history of %s: %v", appName, err)
+	}
+
+	history := make([]HistoryEntry, 0, len(app.Status.History))
+	for _, h := range app.Status.History {
+		entry := HistoryEntry{
+			ID:         h.ID,
+			Revision:   h.Revision,
+			DeployedAt: h.DeployedAt.Time,
+		}
+		if h.DeployStartedAt != nil {
+			entry.DeployStartedAt = h.DeployStartedAt.Time
 		}
 
-		var syncCommit string
-		if app.Status.OperationState != nil &&
-			app.Status.OperationState.SyncResult != nil {
-			syncCommit = app.Status.OperationState.SyncResult.Revision
-		} else {
-			syncCommit = "n/a"
+		source := h.Source
+		if len(h.Sources) > 0 {
+			source = h.Sources[0]
+		}
+		if entry.Revision == "" && len(h.Revisions) > 0 {
+			entry.Revision = h.Revisions[0]
+		}
+		entry.Source = source.RepoURL
+		if source.Chart != "" {
+			entry.Source += " (chart " + source.Chart + ")"
+		} else if source.Path != "" {
+			entry.Source += " (" + source.Path + ")"
 		}
 
-		if len(syncCommit) > 7 {
-			syncCommit = syncCommit[:7]
+		switch {
+		case h.InitiatedBy.Automated:
+			entry.InitiatedBy = "automated"
+		case h.InitiatedBy.Username != "":
+			entry.InitiatedBy = h.InitiatedBy.Username
 		}
+		history = append(history, entry)
+	}
+	return history, nil
+}
 
-		apps = append(apps, Application{
-			Name:         app.Name,
-			HealthStatus: string(app.Status.Health.Status),
-			SyncStatus:   string(app.Status.Sync.Status),
-			SyncCommit:   syncCommit,
-			Project:      app.Spec.Project,
-			LastActivity: lastSyncTime,
+// GetRevisionMetadata returns author and message of a git revision. It fails
+// for Helm chart versions, which carry no metadata.
+func (a *ArgoCdClient) GetRevisionMetadata(appName, revision string) (*RevisionMetadata, error) {
+	var meta *v1alpha1.RevisionMetadata
+	err := a.withAppClient(func(appClient application.ApplicationServiceClient) (err error) {
+		meta, err = appClient.RevisionMetadata(a.ctx, &application.RevisionMetadataQuery{
+			Name:     &appName,
+			Revision: &revision,
 		})
-		// Fill cached search index to avoid recomputing during filtering
-		apps[len(apps)-1].SearchIndex = apps[len(apps)-1].SearchString()
+		return err
+	})
+	if err != nil {
+		return nil, a.logger.Errorf("Error getting metadata of revision %s: %v", revision, err)
 	}
-	return apps, nil
+	return &RevisionMetadata{
+		Author:  meta.Author,
+		Date:    meta.Date.Time,
+		Message: meta.Message,
+		Tags:    meta.Tags,
+	}, nil
 }
 
-func (a *ArgoCdClient) GetAppResources(appName string) ([]Resource, error) {
-	closer, appClient, err := a.client.NewApplicationClient()
+// Rollback redeploys the history entry with the given id.
+func (a *ArgoCdClient) Rollback(appName string, id int64, prune bool) error {
+	defer a.forgetManagedResources(appName)
+	err := a.writeWithAppClient(func(appClient application.ApplicationServiceClient) error {
+		_, err := appClient.Rollback(a.ctx, &application.ApplicationRollbackRequest{
+			Name:  &appName,
+			Id:    &id,
+			Prune: &prune,
+		})
+		return err
+	})
+	if err != nil {
+		return a.logger.Errorf("Error rolling back %s to %d: %v", appName, id, err)
+	}
+	return nil
+}
+
+// toApplication converts an ArgoCD application into the flattened model used by the UI.
+func toApplication(app *v1alpha1.Application) Application {
+	var lastSyncTime string
+
+	if !app.Status.ReconciledAt.IsZero() {
+		lastSyncTime = app.Status.ReconciledAt.Format("2006-01-02 15:04:05")
+	} else if app.Status.OperationState != nil && !app.Status.OperationState.FinishedAt.IsZero() {
+		lastSyncTime = app.Status.OperationState.FinishedAt.Format("2006-01-02 15:04:05")
+	} else if !app.CreationTimestamp.IsZero() {
+		lastSyncTime = app.CreationTimestamp.Format("2006-01-02 15:04:05")
+	} else {
+		lastSyncTime = "n/a"
+	}
+
+	var syncCommit string
+	if app.Status.OperationState != nil &&
+		app.Status.OperationState.SyncResult != nil {
+		syncCommit = app.Status.OperationState.SyncResult.Revision
+	} else {
+		syncCommit = "n/a"
+	}
+
+	if len(syncCommit) > 7 {
+		syncCommit = syncCommit[:7]
+	}
+
+	source := app.Spec.GetSource()
+	result := Application{
+		Name:         app.Name,
+		HealthStatus: string(app.Status.Health.Status),
+		SyncStatus:   string(app.Status.Sync.Status),
+		SyncCommit:   syncCommit,
+		Project:      app.Spec.Project,
+		LastActivity: lastSyncTime,
+
+		RepoURL:        source.RepoURL,
+		Path:           source.Path,
+		Chart:          source.Chart,
+		TargetRevision: source.TargetRevision,
+		SyncRevision:   app.Status.Sync.Revision,
+		DestServer:     app.Spec.Destination.Server,
+		DestName:       app.Spec.Destination.Name,
+		DestNamespace:  app.Spec.Destination.Namespace,
+		HealthMessage:  app.Status.Health.Message,
+		Images:         app.Status.Summary.Images,
+		Labels:         app.Labels,
+		CreatedAt:      app.CreationTimestamp.Time,
+	}
+
+	if policy := app.Spec.SyncPolicy; policy != nil {
+		result.SyncPolicy.SyncOptions = policy.SyncOptions
+		if policy.Automated != nil {
+			result.SyncPolicy.Automated = true
+			result.SyncPolicy.Prune = policy.Automated.Prune
+			result.SyncPolicy.SelfHeal = policy.Automated.SelfHeal
+			result.SyncPolicy.AllowEmpty = policy.Automated.AllowEmpty
+		}
+	}
+
+	for _, cond := range app.Status.Conditions {
+		condition := AppCondition{Type: cond.Type, Message: cond.Message}
+		if cond.LastTransitionTime != nil {
+			condition.LastTransition = cond.LastTransitionTime.Time
+		}
+		result.Conditions = append(result.Conditions, condition)
+	}
+
+	if op := app.Status.OperationState; op != nil {
+		result.Operation = &OperationState{
+			Phase:     string(op.Phase),
+			Message:   op.Message,
+			StartedAt: op.StartedAt.Time,
+		}
+		if op.FinishedAt != nil {
+			result.Operation.FinishedAt = op.FinishedAt.Time
+		}
+		if op.SyncResult != nil {
+			result.Operation.Revision = op.SyncResult.Revision
+			for _, res := range op.SyncResult.Resources {
+				result.Operation.Resources = append(result.Operation.Resources, ResourceResult{
+					Group:     res.Group,
+					Kind:      res.Kind,
+					Namespace: res.Namespace,
+					Name:      res.Name,
+					Status:    string(res.Status),
+					Message:   res.Message,
+					HookType:  string(res.HookType),
+					HookPhase: string(res.HookPhase),
+					SyncPhase: string(res.SyncPhase),
+				})
+			}
+		}
+	}
+	// Fill cached search index to avoid recomputing during filtering
+	result.SearchIndex = result.SearchString()
+	return result
+}
+
+// managedResources returns the managed resources of appName, reusing a
+// response fetched within managedResourcesTTL.
+func (a *ArgoCdClient) managedResources(appName string) (*application.ManagedResourcesResponse, error) {
+	a.managedMu.Lock()
+	cached, ok := a.managed[appName]
+	a.managedMu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < managedResourcesTTL {
+		return cached.resList, nil
+	}
+
+	var resList *application.ManagedResourcesResponse
+	err := a.withAppClient(func(appClient application.ApplicationServiceClient) (err error) {
+		resList, err = appClient.ManagedResources(a.ctx, &application.ResourcesQuery{
+			ApplicationName: &appName,
+		})
+		return err
+	})
 	if err != nil {
-		return nil, a.logger.Errorf("Error creating argocd client: %v", err)
+		return nil, err
 	}
-	defer closer.Close()
 
+	a.managedMu.Lock()
+	defer a.managedMu.Unlock()
+	if a.managed == nil {
+		a.managed = make(map[string]managedResult)
+	}
+	a.managed[appName] = managedResult{resList: resList, fetchedAt: time.Now()}
+	return resList, nil
+}
+
+// forgetManagedResources drops the cached managed resources of appName after
+// a change to the application.
+func (a *ArgoCdClient) forgetManagedResources(appName string) {
+	a.managedMu.Lock()
+	defer a.managedMu.Unlock()
+	delete(a.managed, appName)
+}
+
+func (a *ArgoCdClient) GetAppResources(appName string) ([]Resource, error) {
 	tree, err := a.GetResourceTree(appName)
 	if err != nil {
 		return nil, a.logger.Errorf("Error getting resource tree for %s: %v", appName, err)
 	}
 
-	resList, err := appClient.ManagedResources(a.ctx, &application.ResourcesQuery{
-		ApplicationName: &appName,
-	})
+	resList, err := a.managedResources(appName)
 	if err != nil {
 		return nil, a.logger.Errorf("Error getting managed resources for %s: %v", appName, err)
 	}
@@ -153,16 +440,248 @@ func (a *ArgoCdClient) GetAppResources(appName string) ([]Resource, error) {
 	return resources, nil
 }
 
-func (a *ArgoCdClient) GetResourceTree(appName string) (*v1alpha1.ApplicationTree, error) {
-	closer, appClient, err := a.client.NewApplicationClient()
+// GetResourceDiffs returns live and desired state of every managed resource.
+func (a *ArgoCdClient) GetResourceDiffs(appName string) ([]ResourceDiff, error) {
+	resList, err := a.managedResources(appName)
+	if err != nil {
+		return nil, a.logger.Errorf("Error getting managed resources for %s: %v", appName, err)
+	}
+
+	diffs := make([]ResourceDiff, 0, len(resList.Items))
+	for _, res := range resList.Items {
+		target := res.PredictedLiveState
+		if target == "" {
+			target = res.TargetState
+		}
+		liveYaml, err := jsonToYaml(res.NormalizedLiveState)
+		if err != nil {
+			return nil, a.logger.Errorf("Error decoding live state of %s: %v", res.FullName(), err)
+		}
+		targetYaml, err := jsonToYaml(target)
+		if err != nil {
+			return nil, a.logger.Errorf("Error decoding target state of %s: %v", res.FullName(), err)
+		}
+		diffs = append(diffs, ResourceDiff{
+			Group:       res.Group,
+			Kind:        res.Kind,
+			Namespace:   res.Namespace,
+			Name:        res.Name,
+			LiveState:   liveYaml,
+			TargetState: targetYaml,
+			Modified:    res.Modified,
+			SyncWave:    syncWave(res.TargetState),
+		})
+	}
+	return diffs, nil
+}
+
+// GetResourceStatuses returns the sync state of the managed resources of the
+// application. Hooks are left out, they are not part of the desired state.
+func (a *ArgoCdClient) GetResourceStatuses(appName string) ([]ResourceStatus, error) {
+	resList, err := a.managedResources(appName)
+	if err != nil {
+		return nil, a.logger.Errorf("Error getting managed resources for %s: %v", appName, err)
+	}
+
+	statuses := make([]ResourceStatus, 0, len(resList.Items))
+	for _, res := range resList.Items {
+		if res.Hook {
+			continue
+		}
+		status := ResourceStatus{
+			Group:           res.Group,
+			Kind:            res.Kind,
+			Namespace:       res.Namespace,
+			Name:            res.Name,
+			Status:          "Synced",
+			Missing:         isNullState(res.LiveState),
+			RequiresPruning: isNullState(res.TargetState),
+		}
+		if res.Modified || status.Missing || status.RequiresPruning {
+			status.Status = "OutOfSync"
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// isNullState reports whether a manifest returned by ManagedResources is
+// absent, which ArgoCD encodes as "null".
+func isNullState(manifest string) bool {
+	return manifest == "" || manifest == "null"
+}
+
+// syncWave reads the sync-wave annotation of a JSON manifest, defaulting to 0.
+func syncWave(manifest string) int {
+	var obj struct {
+		Metadata struct {
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal([]byte(manifest), &obj); err != nil {
+		return 0
+	}
+	wave, err := strconv.Atoi(obj.Metadata.Annotations["argocd.argoproj.io/sync-wave"])
+	if err != nil {
+		return 0
+	}
+	return wave
+}
+
+// jsonToYaml converts a manifest as returned by the API; "null" means absent.
+func jsonToYaml(manifest string) (string, error) {
+	if isNullState(manifest) {
+		return "", nil
+	}
+	out, err := yaml.JSONToYAML([]byte(manifest))
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// GetResourceManifest returns the live manifest of ref as JSON.
+func (a *ArgoCdClient) GetResourceManifest(appName string, ref ResourceRef) (string, error) {
+	var resp *application.ApplicationResourceResponse
+	err := a.withAppClient(func(appClient application.ApplicationServiceClient) (err error) {
+		resp, err = appClient.GetResource(a.ctx, &application.ApplicationResourceRequest{
+			Name:         &appName,
+			Namespace:    &ref.Namespace,
+			ResourceName: &ref.Name,
+			Version:      &ref.Version,
+			Group:        &ref.Group,
+			Kind:         &ref.Kind,
+		})
+		return err
+	})
+	if err != nil {
+		return "", a.logger.Errorf("Error getting %s/%s: %v", ref.Kind, ref.Name, err)
+	}
+	return resp.GetManifest(), nil
+}
+
+func (a *ArgoCdClient) ListResourceActions(appName string, ref ResourceRef) ([]ResourceAction, error) {
+	var resp *application.ResourceActionsListResponse
+	err := a.withAppClient(func(appClient application.ApplicationServiceClient) (err error) {
+		resp, err = appClient.ListResourceActions(a.ctx, &application.ApplicationResourceRequest{
+			Name:         &appName,
+			Namespace:    &ref.Namespace,
+			ResourceName: &ref.Name,
+			Version:      &ref.Version,
+			Group:        &ref.Group,
+			Kind:         &ref.Kind,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, a.logger.Errorf("Error listing actions of %s/%s: %v", ref.Kind, ref.Name, err)
+	}
+
+	actions := make([]ResourceAction, 0, len(resp.Actions))
+	for _, action := range resp.Actions {
+		actions = append(actions, ResourceAction{
+			Name:        action.Name,
+			DisplayName: action.DisplayName,
+			Disabled:    action.Disabled,
+		})
+	}
+	return actions, nil
+}
+
+func (a *ArgoCdClient) RunResourceAction(appName string, ref ResourceRef, action string) error {
+	defer a.forgetManagedResources(appName)
+	err := a.writeWithAppClient(func(appClient application.ApplicationServiceClient) error {
+		_, err := appClient.RunResourceAction(a.ctx, &application.ResourceActionRunRequest{
+			Name:         &appName,
+			Namespace:    &ref.Namespace,
+			ResourceName: &ref.Name,
+			Version:      &ref.Version,
+			Group:        &ref.Group,
+			Kind:         &ref.Kind,
+			Action:       &action,
+		})
+		return err
+	})
+	if err != nil {
+		return a.logger.Errorf("Error running action %s on %s/%s: %v", action, ref.Kind, ref.Name, err)
+	}
+	return nil
+}
+
+func (a *ArgoCdClient) DeleteResource(appName string, ref ResourceRef, opts DeleteResourceOptions) error {
+	defer a.forgetManagedResources(appName)
+	err := a.writeWithAppClient(func(appClient application.ApplicationServiceClient) error {
+		_, err := appClient.DeleteResource(a.ctx, &application.ApplicationResourceDeleteRequest{
+			Name:         &appName,
+			Namespace:    &ref.Namespace,
+			ResourceName: &ref.Name,
+			Version:      &ref.Version,
+			Group:        &ref.Group,
+			Kind:         &ref.Kind,
+			Force:        &opts.Force,
+			Orphan:       &opts.Orphan,
+		})
+		return err
+	})
+	if err != nil {
+		return a.logger.Errorf("Error deleting %s %s/%s: %v", ref.Kind, ref.Namespace, ref.Name, err)
+	}
+	return nil
+}
+
+// ListEvents returns the events of ref, or of the Application itself when ref is nil.
+func (a *ArgoCdClient) ListEvents(appName string, ref *ResourceRef) ([]Event, error) {
+	query := &application.ApplicationResourceEventsQuery{Name: &appName}
+	if ref != nil {
+		query.ResourceNamespace = &ref.Namespace
+		query.ResourceName = &ref.Name
+		query.ResourceUID = &ref.UID
+	}
+
+	var eventList *corev1.EventList
+	err := a.withAppClient(func(appClient application.ApplicationServiceClient) (err error) {
+		eventList, err = appClient.ListResourceEvents(a.ctx, query)
+		return err
+	})
 	if err != nil {
-		return nil, a.logger.Errorf("Error creating ArgoCD application client: %v", err)
+		return nil, a.logger.Errorf("Error listing events of %s: %v", appName, err)
 	}
-	defer closer.Close()
+
+	events := make([]Event, 0, len(eventList.Items))
+	for _, ev := range eventList.Items {
+		event := Event{
+			Type:      ev.Type,
+			Reason:    ev.Reason,
+			Message:   ev.Message,
+			Object:    ev.InvolvedObject.Kind + "/" + ev.InvolvedObject.Name,
+			Count:     ev.Count,
+			FirstSeen: ev.FirstTimestamp.Time,
+			LastSeen:  ev.LastTimestamp.Time,
+		}
+		// Events created through the events.k8s.io API only carry EventTime
+		if event.LastSeen.IsZero() {
+			event.LastSeen = ev.EventTime.Time
+		}
+		if event.LastSeen.IsZero() {
+			event.LastSeen = ev.CreationTimestamp.Time
+		}
+		if event.FirstSeen.IsZero() {
+			event.FirstSeen = event.LastSeen
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func (a *ArgoCdClient) GetResourceTree(appName string) (*v1alpha1.ApplicationTree, error) {
 	query := &application.ResourcesQuery{
 		ApplicationName: &appName,
 	}
-	tree, err := appClient.ResourceTree(a.ctx, query)
+	var tree *v1alpha1.ApplicationTree
+	err := a.withAppClient(func(appClient application.ApplicationServiceClient) (err error) {
+		tree, err = appClient.ResourceTree(a.ctx, query)
+		return err
+	})
 	if err != nil {
 		return nil, a.logger.Errorf("Error getting resource tree for %s: %v", appName, err)
 	}
@@ -170,14 +689,13 @@ func (a *ArgoCdClient) GetResourceTree(appName string) (*v1alpha1.ApplicationTre
 }
 
 func (a *ArgoCdClient) RefreshApp(appName string, refreshType string) error {
-	closer, appClient, err := a.client.NewApplicationClient()
-	if err != nil {
-		return a.logger.Errorf("Error getting application client: %+v", err)
-	}
-	defer closer.Close()
-	_, err = appClient.Get(a.ctx, &application.ApplicationQuery{
-		Name:    &appName,
-		Refresh: &refreshType,
+	defer a.forgetManagedResources(appName)
+	err := a.withAppClient(func(appClient application.ApplicationServiceClient) error {
+		_, err := appClient.Get(a.ctx, &application.ApplicationQuery{
+			Name:    &appName,
+			Refresh: &refreshType,
+		})
+		return err
 	})
 	if err != nil {
 		return a.logger.Errorf("Error refreshing app %s: %v", appName, err)
@@ -186,32 +704,88 @@ func (a *ArgoCdClient) RefreshApp(appName string, refreshType string) error {
 	return nil
 }
 
-func (a *ArgoCdClient) SyncApp(appName string) error {
-	closer, appClient, err := a.client.NewApplicationClient()
-	if err != nil {
-		return a.logger.Errorf("Error getting application client: %+v", err)
-	}
-	defer closer.Close()
-	syncRequest := &application.ApplicationSyncRequest{
-		Name: &appName,
-	}
-	_, err = appClient.Sync(a.ctx, syncRequest)
+func (a *ArgoCdClient) SyncApp(appName string, opts SyncOptions) error {
+	defer a.forgetManagedResources(appName)
+	syncRequest := buildSyncRequest(appName, opts)
+	err := a.writeWithAppClient(func(appClient application.ApplicationServiceClient) error {
+		_, err := appClient.Sync(a.ctx, syncRequest)
+		return err
+	})
 	if err != nil {
 		return a.logger.Errorf("Error syncing app %s: %v", appName, err)
 	}
 	return nil
 }
 
-func (a *ArgoCdClient) DeleteApp(appName string) error {
-	closer, appClient, err := a.client.NewApplicationClient()
-	if err != nil {
-		return a.logger.Errorf("Error getting application client: %+v", err)
+// buildSyncRequest translates SyncOptions the same way `argocd app sync` does.
+func buildSyncRequest(appName string, opts SyncOptions) *application.ApplicationSyncRequest {
+	syncRequest := &application.ApplicationSyncRequest{
+		Name:   &appName,
+		DryRun: &opts.DryRun,
+		Prune:  &opts.Prune,
 	}
-	defer closer.Close()
+	if opts.Revision != "" {
+		syncRequest.Revision = &opts.Revision
+	}
+	for _, res := range opts.Resources {
+		syncRequest.Resources = append(syncRequest.Resources, &v1alpha1.SyncOperationResource{
+			Group:     res.Group,
+			Kind:      res.Kind,
+			Namespace: res.Namespace,
+			Name:      res.Name,
+		})
+	}
+
+	if opts.ApplyOnly {
+		syncRequest.Strategy = &v1alpha1.SyncStrategy{
+			Apply: &v1alpha1.SyncStrategyApply{Force: opts.Force},
+		}
+	} else if opts.Force {
+		syncRequest.Strategy = &v1alpha1.SyncStrategy{
+			Hook: &v1alpha1.SyncStrategyHook{
+				SyncStrategyApply: v1alpha1.SyncStrategyApply{Force: true},
+			},
+		}
+	}
+
+	var items []string
+	if opts.ServerSideApply {
+		items = append(items, "ServerSideApply=true")
+	}
+	if opts.Replace {
+		items = append(items, "Replace=true")
+	}
+	if len(items) > 0 {
+		syncRequest.SyncOptions = &application.SyncOptions{Items: items}
+	}
+
+	if opts.RetryLimit > 0 {
+		factor := v1alpha1.DefaultSyncRetryFactor
+		syncRequest.RetryStrategy = &v1alpha1.RetryStrategy{
+			Limit: opts.RetryLimit,
+			Backoff: &v1alpha1.Backoff{
+				Duration:    v1alpha1.DefaultSyncRetryDuration.String(),
+				MaxDuration: v1alpha1.DefaultSyncRetryMaxDuration.String(),
+				Factor:      &factor,
+			},
+		}
+	}
+	return syncRequest
+}
+
+func (a *ArgoCdClient) DeleteApp(appName string, opts DeleteOptions) error {
+	defer a.forgetManagedResources(appName)
 	deleteRequest := &application.ApplicationDeleteRequest{
-		Name: &appName,
+		Name:    &appName,
+		Cascade: &opts.Cascade,
 	}
-	_, err = appClient.Delete(a.ctx, deleteRequest)
+	if opts.Cascade && opts.PropagationPolicy != "" {
+		deleteRequest.PropagationPolicy = &opts.PropagationPolicy
+	}
+	err := a.writeWithAppClient(func(appClient application.ApplicationServiceClient) error {
+		_, err := appClient.Delete(a.ctx, deleteRequest)
+		return err
+	})
 	if err != nil {
 		return a.logger.Errorf("Error deleting app %s: %v", appName, err)
 	}
@@ -225,24 +799,24 @@ func (a *ArgoCdClient) OpenIDConfig(conf *settings.Settings) (*oauth2.Config, *o
 
 // Get ArgoCD Settings via GET /api/v1/settings
 func (a *ArgoCdClient) GetSettings() (*settings.Settings, error) {
-	closer, settingsClient, err := a.client.NewSettingsClient()
-	if err != nil {
-		return nil, a.logger.Errorf("Error getting settings client: %+v", err)
-	}
-	defer closer.Close()
-
-	return settingsClient.Get(a.ctx, &settings.SettingsQuery{})
+	var result *settings.Settings
+	err := a.settingsConn.call(func(settingsClient settings.SettingsServiceClient) (err error) {
+		result, err = settingsClient.Get(a.ctx, &settings.SettingsQuery{})
+		return err
+	})
+	return result, err
 }
 
 func (a *ArgoCdClient) CreateSession(username string, password string) (*session.SessionResponse, error) {
-	closer, sessionClient, err := a.client.NewSessionClient()
-	if err != nil {
-		return nil, a.logger.Errorf("Error getting session client: %+v", err)
-	}
-	defer closer.Close()
 	request := session.SessionCreateRequest{
 		Username: username,
 		Password: password,
 	}
-	return sessionClient.Create(a.ctx, &request)
+	// Creating a session is a write, so it is not retried
+	var result *session.SessionResponse
+	err := a.sessionConn.callOnce(func(sessionClient session.SessionServiceClient) (err error) {
+		result, err = sessionClient.Create(a.ctx, &request)
+		return err
+	})
+	return result, err
 }
@@ -0,0 +1,142 @@
+package argocd
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errClientClosed = errors.New("argocd client is closed")
+
+// cachedConn keeps a single service client (and the gRPC connection behind it)
+// open across calls. A connection that reported a transport failure is dropped
+// and transparently re-dialled on the next call. Streams use a connection of
+// their own from open, so dropping the shared one does not cut them off.
+type cachedConn[T any] struct {
+	mu     sync.Mutex
+	dial   func() (io.Closer, T, error)
+	closer io.Closer
+	client T
+	// gen identifies the cached connection, so that a failure seen on an
+	// older connection does not drop a newer one
+	gen    uint64
+	closed bool
+}
+
+func newCachedConn[T any](dial func() (io.Closer, T, error)) *cachedConn[T] {
+	return &cachedConn[T]{dial: dial}
+}
+
+// get returns the cached client with the generation of its connection.
+func (c *cachedConn[T]) get() (T, uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero T
+	if c.closed {
+		return zero, 0, errClientClosed
+	}
+	if c.closer != nil {
+		return c.client, c.gen, nil
+	}
+
+	closer, client, err := c.dial()
+	if err != nil {
+		return zero, 0, err
+	}
+	c.closer = closer
+	c.client = client
+	c.gen++
+	return client, c.gen, nil
+}
+
+// open dials a connection that is not shared, for a stream. The caller closes
+// it when the stream ends.
+func (c *cachedConn[T]) open() (io.Closer, T, error) {
+	c.mu.Lock()
+	closed := c.closed
+	c.mu.Unlock()
+
+	if closed {
+		var zero T
+		return nil, zero, errClientClosed
+	}
+	return c.dial()
+}
+
+// invalidate drops the cached connection of generation gen so the next get
+// re-dials. A connection dialled since then is kept.
+func (c *cachedConn[T]) invalidate(gen uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.gen == gen {
+		c.reset()
+	}
+}
+
+// drop drops the cached connection whatever its generation.
+func (c *cachedConn[T]) drop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reset()
+}
+
+func (c *cachedConn[T]) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return c.reset()
+}
+
+// reset must be called with c.mu held.
+func (c *cachedConn[T]) reset() error {
+	var zero T
+	closer := c.closer
+	c.closer = nil
+	c.client = zero
+	if closer == nil {
+		return nil
+	}
+	return closer.Close()
+}
+
+// call runs fn with the cached client. When fn fails because the connection is
+// broken, the connection is re-dialled and fn is retried once. Only reads may
+// be retried: a write may have reached the server before the connection broke.
+func (c *cachedConn[T]) call(fn func(T) error) error {
+	err := c.callOnce(fn)
+	if !isConnectionError(err) {
+		return err
+	}
+	return c.callOnce(fn)
+}
+
+// callOnce runs fn with the cached client without retrying. A broken
+// connection is still dropped so that the next call re-dials.
+func (c *cachedConn[T]) callOnce(fn func(T) error) error {
+	client, gen, err := c.get()
+	if err != nil {
+		return err
+	}
+	err = fn(client)
+	if isConnectionError(err) {
+		c.invalidate(gen)
+	}
+	return err
+}
+
+// isConnectionError reports whether err means the underlying connection is
+// unusable, as opposed to the server rejecting the request.
+func isConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	return st.Code() == codes.Unavailable
+}
@@ -0,0 +1,210 @@
+package argocd
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/cluster"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/project"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/repository"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+// appNamePattern is a DNS-1123 subdomain, as required for resource names.
+var appNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
+func (a *ArgoCdClient) ListProjects() ([]Project, error) {
+	var list *v1alpha1.AppProjectList
+	err := a.projectConn.call(func(projectClient project.ProjectServiceClient) (err error) {
+		list, err = projectClient.List(a.ctx, &project.ProjectQuery{})
+		return err
+	})
+	if err != nil {
+		return nil, a.logger.Errorf("Error listing projects: %v", err)
+	}
+
+	projects := make([]Project, 0, len(list.Items))
+	for _, item := range list.Items {
+		p := Project{Name: item.Name, SourceRepos: item.Spec.SourceRepos}
+		for _, dest := range item.Spec.Destinations {
+			p.Destinations = append(p.Destinations, ProjectDestination{
+				Server:    dest.Server,
+				Name:      dest.Name,
+				Namespace: dest.Namespace,
+			})
+		}
+		projects = append(projects, p)
+	}
+	return projects, nil
+}
+
+func (a *ArgoCdClient) ListClusters() ([]Cluster, error) {
+	var list *v1alpha1.ClusterList
+	err := a.clusterConn.call(func(clusterClient cluster.ClusterServiceClient) (err error) {
+		list, err = clusterClient.List(a.ctx, &cluster.ClusterQuery{})
+		return err
+	})
+	if err != nil {
+		return nil, a.logger.Errorf("Error listing clusters: %v", err)
+	}
+
+	clusters := make([]Cluster, 0, len(list.Items))
+	for _, item := range list.Items {
+		clusters = append(clusters, Cluster{Name: item.Name, Server: item.Server})
+	}
+	return clusters, nil
+}
+
+func (a *ArgoCdClient) ListRepositories() ([]Repository, error) {
+	var list *v1alpha1.RepositoryList
+	err := a.repoConn.call(func(repoClient repository.RepositoryServiceClient) (err error) {
+		list, err = repoClient.ListRepositories(a.ctx, &repository.RepoQuery{})
+		return err
+	})
+	if err != nil {
+		return nil, a.logger.Errorf("Error listing repositories: %v", err)
+	}
+
+	repos := make([]Repository, 0, len(list.Items))
+	for _, item := range list.Items {
+		repoType := item.Type
+		if repoType == "" {
+			repoType = "git"
+		}
+		repos = append(repos, Repository{URL: item.Repo, Type: repoType})
+	}
+	return repos, nil
+}
+
+// CreateApp creates the application described by the YAML manifest, as
+// rendered by NewAppManifest. The server validates it before creating.
+func (a *ArgoCdClient) CreateApp(manifest string) error {
+	var app v1alpha1.Application
+	if err := yaml.UnmarshalStrict([]byte(manifest), &app); err != nil {
+		return a.logger.Errorf("Invalid application manifest: %v", err)
+	}
+
+	validate := true
+	err := a.writeWithAppClient(func(appClient application.ApplicationServiceClient) error {
+		_, err := appClient.Create(a.ctx, &application.ApplicationCreateRequest{
+			Application: &app,
+			Validate:    &validate,
+		})
+		return err
+	})
+	if err != nil {
+		return a.logger.Errorf("Error creating application %s: %v", app.Name, err)
+	}
+	return nil
+}
+
+// appManifest is an Application without the status and server populated
+// metadata, which only clutter the preview.
+type appManifest struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Spec v1alpha1.ApplicationSpec `json:"spec"`
+}
+
+// NewAppManifest renders draft as an Application manifest.
+func NewAppManifest(draft AppDraft) (string, error) {
+	app := appManifest{
+		APIVersion: "argoproj.io/v1alpha1",
+		Kind:       "Application",
+		Spec: v1alpha1.ApplicationSpec{
+			Source: &v1alpha1.ApplicationSource{
+				RepoURL:        draft.RepoURL,
+				Path:           draft.Path,
+				Chart:          draft.Chart,
+				TargetRevision: draft.TargetRevision,
+			},
+			Destination: v1alpha1.ApplicationDestination{
+				Server:    draft.DestServer,
+				Namespace: draft.DestNamespace,
+			},
+			Project: draft.Project,
+		},
+	}
+	app.Metadata.Name = draft.Name
+
+	policy := draft.SyncPolicy
+	if policy.Automated || len(policy.SyncOptions) > 0 {
+		app.Spec.SyncPolicy = &v1alpha1.SyncPolicy{SyncOptions: policy.SyncOptions}
+		if policy.Automated {
+			app.Spec.SyncPolicy.Automated = &v1alpha1.SyncPolicyAutomated{
+				Prune:    policy.Prune,
+				SelfHeal: policy.SelfHeal,
+			}
+		}
+	}
+
+	out, err := yaml.Marshal(app)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// ValidateAppName checks that name can be used as an application name.
+func ValidateAppName(name string) error {
+	switch {
+	case name == "":
+		return errors.New("name is required")
+	case len(name) > 253:
+		return errors.New("name must be at most 253 characters")
+	case !appNamePattern.MatchString(name):
+		return fmt.Errorf("name %q must consist of lower case letters, digits, '-' and '.'", name)
+	}
+	return nil
+}
+
+// AllowsSource reports whether the project permits deploying from repoURL.
+func (p Project) AllowsSource(repoURL string) bool {
+	for _, pattern := range p.SourceRepos {
+		if matchWildcard(pattern, repoURL) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsDestination reports whether the project permits deploying to the
+// namespace of the cluster with the given server URL and name.
+func (p Project) AllowsDestination(server, name, namespace string) bool {
+	for _, dest := range p.Destinations {
+		clusterOK := (dest.Server != "" && matchWildcard(dest.Server, server)) ||
+			(dest.Name != "" && matchWildcard(dest.Name, name))
+		if clusterOK && matchWildcard(dest.Namespace, namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchWildcard matches value against pattern, where '*' matches any
+// sequence of characters including '/'.
+func matchWildcard(pattern, value string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == value
+	}
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(value, part)
+		if i < 0 {
+			return false
+		}
+		value = value[i+len(part):]
+	}
+	return strings.HasSuffix(value, parts[len(parts)-1])
+}
@@ -0,0 +1,801 @@
+// Package fake provides a deterministic in-memory argocd.Backend so the UI
+// screens can be driven without a running ArgoCD server.
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+)
+
+// Call records a single invocation of a Backend method.
+type Call struct {
+	Method string
+	Args   []string
+}
+
+// Transition is one scripted state change applied by Step. App is upserted
+// (or removed for EventDeleted) and Tree, when set, replaces the resource tree
+// of App.Name. Watchers are notified of both.
+type Transition struct {
+	Type argocd.EventType
+	App  argocd.Application
+	Tree *v1alpha1.ApplicationTree
+}
+
+type treeWatcher struct {
+	appName string
+	onTree  func(*v1alpha1.ApplicationTree)
+}
+
+type Backend struct {
+	mu         sync.Mutex
+	apps       []argocd.Application
+	trees      map[string]*v1alpha1.ApplicationTree
+	resources  map[string][]argocd.Resource
+	history    map[string][]argocd.HistoryEntry
+	revisions  map[string]argocd.RevisionMetadata
+	diffs      map[string][]argocd.ResourceDiff
+	manifests  map[string]string
+	actions    map[string][]argocd.ResourceAction
+	events     map[string][]argocd.Event
+	containers map[string][]string
+	logs       map[string][]argocd.LogLine
+	versions   map[string]int
+	specs      map[string]v1alpha1.ApplicationSpec
+	parameters map[string]argocd.AppParameters
+	projects   []argocd.Project
+	clusters   []argocd.Cluster
+	repos      []argocd.Repository
+	failures   map[string]error
+	script     []Transition
+	calls      []Call
+
+	nextWatcherID int
+	appWatchers   map[int]func(argocd.AppEvent)
+	treeWatchers  map[int]treeWatcher
+}
+
+func New(apps ...argocd.Application) *Backend {
+	b := &Backend{
+		trees:        make(map[string]*v1alpha1.ApplicationTree),
+		resources:    make(map[string][]argocd.Resource),
+		history:      make(map[string][]argocd.HistoryEntry),
+		revisions:    make(map[string]argocd.RevisionMetadata),
+		diffs:        make(map[string][]argocd.ResourceDiff),
+		manifests:    make(map[string]string),
+		actions:      make(map[string][]argocd.ResourceAction),
+		events:       make(map[string][]argocd.Event),
+		containers:   make(map[string][]string),
+		logs:         make(map[string][]argocd.LogLine),
+		versions:     make(map[string]int),
+		specs:        make(map[string]v1alpha1.ApplicationSpec),
+		parameters:   make(map[string]argocd.AppParameters),
+		failures:     make(map[string]error),
+		appWatchers:  make(map[int]func(argocd.AppEvent)),
+		treeWatchers: make(map[int]treeWatcher),
+	}
+	for _, app := range apps {
+		b.apps = append(b.apps, withSearchIndex(app))
+	}
+	return b
+}
+
+// SetResourceTree sets the tree returned by GetResourceTree and WatchResourceTree.
+func (b *Backend) SetResourceTree(appName string, tree *v1alpha1.ApplicationTree) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trees[appName] = tree
+}
+
+// SetResources sets the managed resources returned by GetAppResources.
+func (b *Backend) SetResources(appName string, resources []argocd.Resource) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.resources[appName] = resources
+}
+
+// SetHistory sets the deployment history returned by GetHistory, oldest first.
+func (b *Backend) SetHistory(appName string, history []argocd.HistoryEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.history[appName] = history
+}
+
+// SetRevisionMetadata sets the metadata returned for revision. Revisions
+// without metadata make GetRevisionMetadata fail, like Helm chart versions do.
+func (b *Backend) SetRevisionMetadata(revision string, meta argocd.RevisionMetadata) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.revisions[revision] = meta
+}
+
+// SetResourceDiffs sets the live/desired states returned by GetResourceDiffs.
+func (b *Backend) SetResourceDiffs(appName string, diffs []argocd.ResourceDiff) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.diffs[appName] = diffs
+}
+
+// SetParameterDefaults sets the source type and default parameters reported
+// by GetAppParameters before the overrides of the spec are applied.
+func (b *Backend) SetParameterDefaults(appName string, defaults argocd.AppParameters) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.parameters[appName] = defaults
+}
+
+// SetManifest sets the JSON manifest returned by GetResourceManifest for ref.
+func (b *Backend) SetManifest(appName string, ref argocd.ResourceRef, manifest string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.manifests[refKey(appName, ref)] = manifest
+}
+
+// SetActions sets the resource actions returned by ListResourceActions for ref.
+func (b *Backend) SetActions(appName string, ref argocd.ResourceRef, actions []argocd.ResourceAction) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.actions[refKey(appName, ref)] = actions
+}
+
+// SetEvents sets the events returned by ListEvents for ref, or for the
+// application itself when ref is nil.
+func (b *Backend) SetEvents(appName string, ref *argocd.ResourceRef, events []argocd.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.events[eventsKey(appName, ref)] = events
+}
+
+// SetContainers sets the container names returned by GetContainers for ref.
+func (b *Backend) SetContainers(appName string, ref argocd.ResourceRef, names []string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.containers[refKey(appName, ref)] = names
+}
+
+// SetLogs sets the lines sent by StreamPodLogs for ref, regardless of the container.
+func (b *Backend) SetLogs(appName string, ref argocd.ResourceRef, lines []argocd.LogLine) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.logs[refKey(appName, ref)] = lines
+}
+
+// FailOn makes every subsequent call of method return err. A nil err clears it.
+func (b *Backend) FailOn(method string, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		delete(b.failures, method)
+		return
+	}
+	b.failures[method] = err
+}
+
+// Script queues transitions to be applied one at a time by Step.
+func (b *Backend) Script(transitions ...Transition) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.script = append(b.script, transitions...)
+}
+
+// Step applies the next scripted transition and notifies watchers.
+// It returns false once the script is exhausted.
+func (b *Backend) Step() bool {
+	b.mu.Lock()
+	if len(b.script) == 0 {
+		b.mu.Unlock()
+		return false
+	}
+	t := b.script[0]
+	b.script = b.script[1:]
+	b.mu.Unlock()
+
+	if t.Type != "" {
+		b.applyAppEvent(argocd.AppEvent{Type: t.Type, App: t.App})
+	}
+	if t.Tree != nil {
+		b.applyTree(t.App.Name, t.Tree)
+	}
+	return true
+}
+
+// Calls returns the recorded method invocations in order.
+func (b *Backend) Calls() []Call {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	calls := make([]Call, len(b.calls))
+	copy(calls, b.calls)
+	return calls
+}
+
+func (b *Backend) GetApps() ([]argocd.Application, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.record("GetApps"); err != nil {
+		return nil, err
+	}
+	apps := make([]argocd.Application, len(b.apps))
+	copy(apps, b.apps)
+	return apps, nil
+}
+
+func (b *Backend) GetApp(appName string) (argocd.Application, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.record("GetApp", appName); err != nil {
+		return argocd.Application{}, err
+	}
+	idx := b.indexOf(appName)
+	if idx < 0 {
+		return argocd.Application{}, fmt.Errorf("application %s not found", appName)
+	}
+	return b.apps[idx], nil
+}
+
+func (b *Backend) GetHistory(appName string) ([]argocd.HistoryEntry, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.record("GetHistory", appName); err != nil {
+		return nil, err
+	}
+	return b.history[appName], nil
+}
+
+func (b *Backend) GetRevisionMetadata(appName, revision string) (*argocd.RevisionMetadata, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.record("GetRevisionMetadata", appName, revision); err != nil {
+		return nil, err
+	}
+	meta, ok := b.revisions[revision]
+	if !ok {
+		return nil, fmt.Errorf("revision %s not found", revision)
+	}
+	return &meta, nil
+}
+
+// TerminateOperation fails a running operation and emits a MODIFIED event.
+func (b *Backend) TerminateOperation(appName string) error {
+	b.mu.Lock()
+	if err := b.record("TerminateOperation", appName); err != nil {
+		b.mu.Unlock()
+		return err
+	}
+	idx := b.indexOf(appName)
+	if idx < 0 {
+		b.mu.Unlock()
+		return fmt.Errorf("application %s not found", appName)
+	}
+	app := b.apps[idx]
+	b.mu.Unlock()
+
+	if app.Operation == nil || app.Operation.Phase != "Running" {
+		return fmt.Errorf("no operation is in progress for %s", appName)
+	}
+	op := *app.Operation
+	op.Phase = "Failed"
+	op.Message = "Operation terminated"
+	app.Operation = &op
+	b.applyAppEvent(argocd.AppEvent{Type: argocd.EventModified, App: app})
+	return nil
+}
+
+// Rollback appends a new history entry for the redeployed revision and emits
+// a MODIFIED event.
+func (b *Backend) Rollback(appName string, id int64, prune bool) error {
+	b.mu.Lock()
+	if err := b.record("Rollback", appName, fmt.Sprint(id), fmt.Sprint(prune)); err != nil {
+		b.mu.Unlock()
+		return err
+	}
+	idx := b.indexOf(appName)
+	if idx < 0 {
+		b.mu.Unlock()
+		return fmt.Errorf("application %s not found", appName)
+	}
+	history := b.history[appName]
+	var target *argocd.HistoryEntry
+	for i := range history {
+		if history[i].ID == id {
+			target = &history[i]
+		}
+	}
+	if target == nil {
+		b.mu.Unlock()
+		return fmt.Errorf("history entry %d not found", id)
+	}
+	entry := *target
+	entry.ID = history[len(history)-1].ID + 1
+	b.history[appName] = append(history, entry)
+	app := b.apps[idx]
+	b.mu.Unlock()
+
+	app.SyncStatus = "OutOfSync"
+	b.applyAppEvent(argocd.AppEvent{Type: argocd.EventModified, App: app})
+	return nil
+}
+
+func (b *Backend) GetAppResources(appName string) ([]argocd.Resource, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.record("GetAppResources", appName); err != nil {
+		return nil, err
+	}
+	return b.resources[appName], nil
+}
+
+func (b *Backend) GetResourceDiffs(appName string) ([]argocd.ResourceDiff, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.record("GetResourceDiffs", appName); err != nil {
+		return nil, err
+	}
+	return b.diffs[appName], nil
+}
+
+// GetResourceStatuses derives the sync state from the diffs set with
+// SetResourceDiffs: an empty side marks a missing or extraneous resource.
+func (b *Backend) GetResourceStatuses(appName string) ([]argocd.ResourceStatus, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.record("GetResourceStatuses", appName); err != nil {
+		return nil, err
+	}
+	statuses := make([]argocd.ResourceStatus, 0, len(b.diffs[appName]))
+	for _, d := range b.diffs[appName] {
+		status := argocd.ResourceStatus{
+			Group:           d.Group,
+			Kind:            d.Kind,
+			Namespace:       d.Namespace,
+			Name:            d.Name,
+			Status:          "Synced",
+			Missing:         d.LiveState == "",
+			RequiresPruning: d.TargetState == "",
+		}
+		if d.Modified || status.Missing || status.RequiresPruning {
+			status.Status = "OutOfSync"
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (b *Backend) GetResourceManifest(appName string, ref argocd.ResourceRef) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.record("GetResourceManifest", appName, ref.Kind, ref.Name); err != nil {
+		return "", err
+	}
+	manifest, ok := b.manifests[refKey(appName, ref)]
+	if !ok {
+		return "", fmt.Errorf("%s/%s not found", ref.Kind, ref.Name)
+	}
+	return manifest, nil
+}
+
+func (b *Backend) GetResourceTree(appName string) (*v1alpha1.ApplicationTree, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.record("GetResourceTree", appName); err != nil {
+		return nil, err
+	}
+	if tree, ok := b.trees[appName]; ok {
+		return tree.DeepCopy(), nil
+	}
+	return &v1alpha1.ApplicationTree{}, nil
+}
+
+func (b *Backend) RefreshApp(appName string, refreshType string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.record("RefreshApp", appName, refreshType); err != nil {
+		return err
+	}
+	if b.indexOf(appName) < 0 {
+		return fmt.Errorf("application %s not found", appName)
+	}
+	return nil
+}
+
+// SyncApp marks the application as Synced and emits a MODIFIED event.
+// Dry runs are recorded but leave the state untouched.
+func (b *Backend) SyncApp(appName string, opts argocd.SyncOptions) error {
+	b.mu.Lock()
+	if err := b.record("SyncApp", appName, fmt.Sprintf("%+v", opts)); err != nil {
+		b.mu.Unlock()
+		return err
+	}
+	idx := b.indexOf(appName)
+	if idx < 0 {
+		b.mu.Unlock()
+		return fmt.Errorf("application %s not found", appName)
+	}
+	app := b.apps[idx]
+	b.mu.Unlock()
+
+	if opts.DryRun {
+		return nil
+	}
+	app.SyncStatus = "Synced"
+	b.applyAppEvent(argocd.AppEvent{Type: argocd.EventModified, App: app})
+	return nil
+}
+
+// DeleteApp removes the application and emits a DELETED event.
+func (b *Backend) DeleteApp(appName string, opts argocd.DeleteOptions) error {
+	b.mu.Lock()
+	if err := b.record("DeleteApp", appName, fmt.Sprintf("%+v", opts)); err != nil {
+		b.mu.Unlock()
+		return err
+	}
+	idx := b.indexOf(appName)
+	if idx < 0 {
+		b.mu.Unlock()
+		return fmt.Errorf("application %s not found", appName)
+	}
+	app := b.apps[idx]
+	b.mu.Unlock()
+
+	b.applyAppEvent(argocd.AppEvent{Type: argocd.EventDeleted, App: app})
+	return nil
+}
+
+func (b *Backend) ListEvents(appName string, ref *argocd.ResourceRef) ([]argocd.Event, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	args := []string{appName}
+	if ref != nil {
+		args = append(args, ref.Kind, ref.Name)
+	}
+	if err := b.record("ListEvents", args...); err != nil {
+		return nil, err
+	}
+	return b.events[eventsKey(appName, ref)], nil
+}
+
+func (b *Backend) ListResourceActions(appName string, ref argocd.ResourceRef) ([]argocd.ResourceAction, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.record("ListResourceActions", appName, ref.Kind, ref.Name); err != nil {
+		return nil, err
+	}
+	return b.actions[refKey(appName, ref)], nil
+}
+
+// RunResourceAction only records the call; it fails for unknown or disabled actions.
+func (b *Backend) RunResourceAction(appName string, ref argocd.ResourceRef, action string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.record("RunResourceAction", appName, ref.Kind, ref.Name, action); err != nil {
+		return err
+	}
+	for _, a := range b.actions[refKey(appName, ref)] {
+		if a.Name == action && !a.Disabled {
+			return nil
+		}
+	}
+	return fmt.Errorf("action %s is not available for %s/%s", action, ref.Kind, ref.Name)
+}
+
+// DeleteResource removes the node from the resource tree, along with its
+// descendants unless they are orphaned, and notifies the tree watchers.
+func (b *Backend) DeleteResource(appName string, ref argocd.ResourceRef, opts argocd.DeleteResourceOptions) error {
+	b.mu.Lock()
+	if err := b.record("DeleteResource", appName, ref.Kind, ref.Namespace, ref.Name, fmt.Sprintf("%+v", opts)); err != nil {
+		b.mu.Unlock()
+		return err
+	}
+	tree, ok := b.trees[appName]
+	if !ok {
+		b.mu.Unlock()
+		return fmt.Errorf("application %s not found", appName)
+	}
+
+	// Orphaned nodes can be deleted through the application as well
+	nodes := append(append([]v1alpha1.ResourceNode(nil), tree.Nodes...), tree.OrphanedNodes...)
+	removed := make(map[string]bool)
+	for _, node := range nodes {
+		if node.Kind == ref.Kind && node.Namespace == ref.Namespace && node.Name == ref.Name {
+			removed[node.UID] = true
+		}
+	}
+	if len(removed) == 0 {
+		b.mu.Unlock()
+		return fmt.Errorf("%s %s/%s not found", ref.Kind, ref.Namespace, ref.Name)
+	}
+	// Without orphaning, the garbage collector takes the dependents too
+	for grown := !opts.Orphan; grown; {
+		grown = false
+		for _, node := range nodes {
+			if removed[node.UID] {
+				continue
+			}
+			for _, parent := range node.ParentRefs {
+				if removed[parent.UID] {
+					removed[node.UID] = true
+					grown = true
+					break
+				}
+			}
+		}
+	}
+
+	updated := tree.DeepCopy()
+	updated.Nodes = remainingNodes(tree.Nodes, removed)
+	updated.OrphanedNodes = remainingNodes(tree.OrphanedNodes, removed)
+	b.mu.Unlock()
+
+	b.applyTree(appName, updated)
+	return nil
+}
+
+func remainingNodes(nodes []v1alpha1.ResourceNode, removed map[string]bool) []v1alpha1.ResourceNode {
+	var remaining []v1alpha1.ResourceNode
+	for _, node := range nodes {
+		if !removed[node.UID] {
+			remaining = append(remaining, *node.DeepCopy())
+		}
+	}
+	return remaining
+}
+
+func (b *Backend) GetContainers(appName string, ref argocd.ResourceRef) ([]string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.record("GetContainers", appName, ref.Kind, ref.Name); err != nil {
+		return nil, err
+	}
+	return b.containers[refKey(appName, ref)], nil
+}
+
+// StreamPodLogs sends the configured lines, honouring TailLines, and then
+// blocks until ctx is cancelled when following.
+func (b *Backend) StreamPodLogs(
+	ctx context.Context,
+	appName string,
+	ref argocd.ResourceRef,
+	opts argocd.LogOptions,
+	onLine func(argocd.LogLine),
+) error {
+	b.mu.Lock()
+	if err := b.record("StreamPodLogs", appName, ref.Kind, ref.Name, fmt.Sprintf("%+v", opts)); err != nil {
+		b.mu.Unlock()
+		return err
+	}
+	lines := b.logs[refKey(appName, ref)]
+	b.mu.Unlock()
+
+	if opts.TailLines > 0 && int64(len(lines)) > opts.TailLines {
+		lines = lines[int64(len(lines))-opts.TailLines:]
+	}
+	for _, line := range lines {
+		if ctx.Err() != nil {
+			return nil
+		}
+		onLine(line)
+	}
+	if opts.Follow {
+		<-ctx.Done()
+	}
+	return nil
+}
+
+// WatchApps replays the current applications as ADDED events, like the real
+// server does, then delivers scripted changes until ctx is cancelled.
+func (b *Backend) WatchApps(ctx context.Context, onEvent func(argocd.AppEvent), onStatus argocd.WatchStatusHandler) {
+	b.mu.Lock()
+	if err := b.record("WatchApps"); err != nil {
+		b.mu.Unlock()
+		if onStatus != nil {
+			onStatus(false, err)
+		}
+		return
+	}
+	id := b.nextWatcherID
+	b.nextWatcherID++
+	b.appWatchers[id] = onEvent
+	apps := make([]argocd.Application, len(b.apps))
+	copy(apps, b.apps)
+	b.mu.Unlock()
+
+	if onStatus != nil {
+		onStatus(true, nil)
+	}
+	for _, app := range apps {
+		onEvent(argocd.AppEvent{Type: argocd.EventAdded, App: app})
+	}
+
+	<-ctx.Done()
+	b.mu.Lock()
+	delete(b.appWatchers, id)
+	b.mu.Unlock()
+}
+
+// WatchApp sends the current state of appName, then every scripted change to
+// it until ctx is cancelled.
+func (b *Backend) WatchApp(ctx context.Context, appName string, onApp func(argocd.Application), onStatus argocd.WatchStatusHandler) {
+	b.mu.Lock()
+	if err := b.record("WatchApp", appName); err != nil {
+		b.mu.Unlock()
+		if onStatus != nil {
+			onStatus(false, err)
+		}
+		return
+	}
+	id := b.nextWatcherID
+	b.nextWatcherID++
+	b.appWatchers[id] = func(ev argocd.AppEvent) {
+		if ev.App.Name == appName && ev.Type != argocd.EventDeleted {
+			onApp(ev.App)
+		}
+	}
+	var current *argocd.Application
+	if idx := b.indexOf(appName); idx >= 0 {
+		app := b.apps[idx]
+		current = &app
+	}
+	b.mu.Unlock()
+
+	if onStatus != nil {
+		onStatus(true, nil)
+	}
+	if current != nil {
+		onApp(*current)
+	}
+
+	<-ctx.Done()
+	b.mu.Lock()
+	delete(b.appWatchers, id)
+	b.mu.Unlock()
+}
+
+// WatchResourceTree sends the current tree, then every scripted tree for
+// appName until ctx is cancelled.
+func (b *Backend) WatchResourceTree(
+	ctx context.Context,
+	appName string,
+	onTree func(*v1alpha1.ApplicationTree),
+	onStatus argocd.WatchStatusHandler,
+) {
+	b.mu.Lock()
+	if err := b.record("WatchResourceTree", appName); err != nil {
+		b.mu.Unlock()
+		if onStatus != nil {
+			onStatus(false, err)
+		}
+		return
+	}
+	id := b.nextWatcherID
+	b.nextWatcherID++
+	b.treeWatchers[id] = treeWatcher{appName: appName, onTree: onTree}
+	tree, hasTree := b.trees[appName]
+	b.mu.Unlock()
+
+	if onStatus != nil {
+		onStatus(true, nil)
+	}
+	if hasTree {
+		onTree(tree.DeepCopy())
+	}
+
+	<-ctx.Done()
+	b.mu.Lock()
+	delete(b.treeWatchers, id)
+	b.mu.Unlock()
+}
+
+// record must be called with b.mu held.
+func (b *Backend) record(method string, args ...string) error {
+	b.calls = append(b.calls, Call{Method: method, Args: args})
+	return b.failures[method]
+}
+
+// indexOf must be called with b.mu held.
+func (b *Backend) indexOf(appName string) int {
+	for i := range b.apps {
+		if b.apps[i].Name == appName {
+			return i
+		}
+	}
+	return -1
+}
+
+func (b *Backend) applyAppEvent(ev argocd.AppEvent) {
+	ev.App = withSearchIndex(ev.App)
+
+	b.mu.Lock()
+	idx := b.indexOf(ev.App.Name)
+	switch ev.Type {
+	case argocd.EventDeleted:
+		if idx >= 0 {
+			b.apps = append(b.apps[:idx:idx], b.apps[idx+1:]...)
+		}
+		delete(b.trees, ev.App.Name)
+		delete(b.resources, ev.App.Name)
+		delete(b.history, ev.App.Name)
+		delete(b.diffs, ev.App.Name)
+		delete(b.versions, ev.App.Name)
+		delete(b.specs, ev.App.Name)
+		delete(b.parameters, ev.App.Name)
+		deleteAppKeys(b.logs, ev.App.Name)
+		deleteAppKeys(b.containers, ev.App.Name)
+		deleteAppKeys(b.manifests, ev.App.Name)
+		deleteAppKeys(b.actions, ev.App.Name)
+		deleteAppKeys(b.events, ev.App.Name)
+	default:
+		if idx >= 0 {
+			b.apps[idx] = ev.App
+		} else {
+			b.apps = append(b.apps, ev.App)
+		}
+	}
+	watchers := make([]func(argocd.AppEvent), 0, len(b.appWatchers))
+	for _, id := range sortedIDs(b.appWatchers) {
+		watchers = append(watchers, b.appWatchers[id])
+	}
+	b.mu.Unlock()
+
+	for _, w := range watchers {
+		w(ev)
+	}
+}
+
+func (b *Backend) applyTree(appName string, tree *v1alpha1.ApplicationTree) {
+	b.mu.Lock()
+	b.trees[appName] = tree
+	var watchers []func(*v1alpha1.ApplicationTree)
+	for _, id := range sortedIDs(b.treeWatchers) {
+		if w := b.treeWatchers[id]; w.appName == appName {
+			watchers = append(watchers, w.onTree)
+		}
+	}
+	b.mu.Unlock()
+
+	for _, w := range watchers {
+		w(tree.DeepCopy())
+	}
+}
+
+func refKey(appName string, ref argocd.ResourceRef) string {
+	return fmt.Sprintf("%s|%s|%s|%s", appName, ref.Kind, ref.Namespace, ref.Name)
+}
+
+func eventsKey(appName string, ref *argocd.ResourceRef) string {
+	if ref == nil {
+		return appName + "|"
+	}
+	return refKey(appName, *ref)
+}
+
+// deleteAppKeys drops the per-resource entries of a deleted application.
+func deleteAppKeys[T any](m map[string]T, appName string) {
+	for key := range m {
+		if strings.HasPrefix(key, appName+"|") {
+			delete(m, key)
+		}
+	}
+}
+
+// sortedIDs keeps watcher notification order stable between runs.
+func sortedIDs[T any](watchers map[int]T) []int {
+	ids := make([]int, 0, len(watchers))
+	for id := range watchers {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func withSearchIndex(app argocd.Application) argocd.Application {
+	app.SearchIndex = ""
+	app.SearchIndex = app.SearchString()
+	return app
+}
+
+var _ argocd.Backend = (*Backend)(nil)
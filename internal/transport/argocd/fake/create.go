@@ -0,0 +1,90 @@
+package fake
+
+import (
+	"fmt"
+
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+// SetProjects sets the projects returned by ListProjects.
+func (b *Backend) SetProjects(projects []argocd.Project) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.projects = projects
+}
+
+// SetClusters sets the clusters returned by ListClusters.
+func (b *Backend) SetClusters(clusters []argocd.Cluster) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.clusters = clusters
+}
+
+// SetRepositories sets the repositories returned by ListRepositories.
+func (b *Backend) SetRepositories(repos []argocd.Repository) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.repos = repos
+}
+
+func (b *Backend) ListProjects() ([]argocd.Project, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.record("ListProjects"); err != nil {
+		return nil, err
+	}
+	return b.projects, nil
+}
+
+func (b *Backend) ListClusters() ([]argocd.Cluster, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.record("ListClusters"); err != nil {
+		return nil, err
+	}
+	return b.clusters, nil
+}
+
+func (b *Backend) ListRepositories() ([]argocd.Repository, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.record("ListRepositories"); err != nil {
+		return nil, err
+	}
+	return b.repos, nil
+}
+
+// CreateApp adds an OutOfSync application built from the manifest and emits
+// an ADDED event.
+func (b *Backend) CreateApp(manifest string) error {
+	var parsed v1alpha1.Application
+	if err := yaml.UnmarshalStrict([]byte(manifest), &parsed); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	if err := b.record("CreateApp", parsed.Name); err != nil {
+		b.mu.Unlock()
+		return err
+	}
+	if b.indexOf(parsed.Name) >= 0 {
+		b.mu.Unlock()
+		return fmt.Errorf("application %s already exists", parsed.Name)
+	}
+	b.specs[parsed.Name] = parsed.Spec
+	b.mu.Unlock()
+
+	app := argocd.Application{
+		Name:         parsed.Name,
+		HealthStatus: "Missing",
+		SyncStatus:   "OutOfSync",
+		SyncCommit:   "n/a",
+		LastActivity: "n/a",
+		Labels:       parsed.Labels,
+	}
+	applySpec(&app, parsed.Spec)
+	b.applyAppEvent(argocd.AppEvent{Type: argocd.EventAdded, App: app})
+	return nil
+}
@@ -0,0 +1,167 @@
+package fake
+
+import (
+	"fmt"
+
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+// GetAppSpec renders the spec from the fields of the application model.
+func (b *Backend) GetAppSpec(appName string) (argocd.AppSpec, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.record("GetAppSpec", appName); err != nil {
+		return argocd.AppSpec{}, err
+	}
+	idx := b.indexOf(appName)
+	if idx < 0 {
+		return argocd.AppSpec{}, fmt.Errorf("application %s not found", appName)
+	}
+	out, err := yaml.Marshal(b.specOf(b.apps[idx]))
+	if err != nil {
+		return argocd.AppSpec{}, err
+	}
+	return argocd.AppSpec{YAML: string(out), ResourceVersion: fmt.Sprint(b.versions[appName])}, nil
+}
+
+// GetAppParameters applies the Helm and Kustomize overrides of the spec on
+// top of the defaults set with SetParameterDefaults.
+func (b *Backend) GetAppParameters(appName string) (argocd.AppParameters, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.record("GetAppParameters", appName); err != nil {
+		return argocd.AppParameters{}, err
+	}
+	idx := b.indexOf(appName)
+	if idx < 0 {
+		return argocd.AppParameters{}, fmt.Errorf("application %s not found", appName)
+	}
+	spec := b.specOf(b.apps[idx])
+	return argocd.ParametersWithOverrides(b.parameters[appName], spec.Source), nil
+}
+
+// UpdateAppSpec copies the edited spec onto the application model and emits
+// a MODIFIED event. Every spec change bumps the resource version, so a stale
+// spec fails with argocd.ErrConflict.
+func (b *Backend) UpdateAppSpec(appName string, spec argocd.AppSpec) error {
+	if err := argocd.ValidateAppSpec(spec.YAML); err != nil {
+		return err
+	}
+	var parsed v1alpha1.ApplicationSpec
+	if err := yaml.Unmarshal([]byte(spec.YAML), &parsed); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	if err := b.record("UpdateAppSpec", appName, spec.ResourceVersion); err != nil {
+		b.mu.Unlock()
+		return err
+	}
+	idx := b.indexOf(appName)
+	if idx < 0 {
+		b.mu.Unlock()
+		return fmt.Errorf("application %s not found", appName)
+	}
+	if fmt.Sprint(b.versions[appName]) != spec.ResourceVersion {
+		b.mu.Unlock()
+		return fmt.Errorf("error updating %s: %w", appName, argocd.ErrConflict)
+	}
+	app := b.apps[idx]
+	b.versions[appName]++
+	b.specs[appName] = parsed
+	b.mu.Unlock()
+
+	applySpec(&app, parsed)
+	b.applyAppEvent(argocd.AppEvent{Type: argocd.EventModified, App: app})
+	return nil
+}
+
+// SetSyncPolicy replaces the automated policy and emits a MODIFIED event.
+func (b *Backend) SetSyncPolicy(appName string, policy argocd.SyncPolicy) error {
+	b.mu.Lock()
+	if err := b.record("SetSyncPolicy", appName,
+		fmt.Sprint(policy.Automated), fmt.Sprint(policy.Prune), fmt.Sprint(policy.SelfHeal)); err != nil {
+		b.mu.Unlock()
+		return err
+	}
+	idx := b.indexOf(appName)
+	if idx < 0 {
+		b.mu.Unlock()
+		return fmt.Errorf("application %s not found", appName)
+	}
+	app := b.apps[idx]
+	b.versions[appName]++
+	b.mu.Unlock()
+
+	policy.SyncOptions = app.SyncPolicy.SyncOptions
+	if !policy.Automated {
+		policy = argocd.SyncPolicy{SyncOptions: policy.SyncOptions}
+	}
+	app.SyncPolicy = policy
+	b.applyAppEvent(argocd.AppEvent{Type: argocd.EventModified, App: app})
+	return nil
+}
+
+// specOf renders the spec of app. The last spec written by UpdateAppSpec
+// provides the fields the application model does not carry, such as Helm
+// and Kustomize settings. Must be called with b.mu held.
+func (b *Backend) specOf(app argocd.Application) v1alpha1.ApplicationSpec {
+	stored := b.specs[app.Name]
+	spec := *stored.DeepCopy()
+	if spec.Source == nil {
+		spec.Source = &v1alpha1.ApplicationSource{}
+	}
+	spec.Source.RepoURL = app.RepoURL
+	spec.Source.Path = app.Path
+	spec.Source.Chart = app.Chart
+	spec.Source.TargetRevision = app.TargetRevision
+	spec.Destination = v1alpha1.ApplicationDestination{
+		Server:    app.DestServer,
+		Name:      app.DestName,
+		Namespace: app.DestNamespace,
+	}
+	spec.Project = app.Project
+
+	if spec.SyncPolicy == nil {
+		spec.SyncPolicy = &v1alpha1.SyncPolicy{}
+	}
+	policy := app.SyncPolicy
+	spec.SyncPolicy.SyncOptions = policy.SyncOptions
+	spec.SyncPolicy.Automated = nil
+	if policy.Automated {
+		spec.SyncPolicy.Automated = &v1alpha1.SyncPolicyAutomated{
+			Prune:      policy.Prune,
+			SelfHeal:   policy.SelfHeal,
+			AllowEmpty: policy.AllowEmpty,
+		}
+	}
+	if spec.SyncPolicy.IsZero() {
+		spec.SyncPolicy = nil
+	}
+	return spec
+}
+
+// applySpec copies the fields of spec the application model carries.
+func applySpec(app *argocd.Application, spec v1alpha1.ApplicationSpec) {
+	source := spec.GetSource()
+	app.RepoURL = source.RepoURL
+	app.Path = source.Path
+	app.Chart = source.Chart
+	app.TargetRevision = source.TargetRevision
+	app.DestServer = spec.Destination.Server
+	app.DestName = spec.Destination.Name
+	app.DestNamespace = spec.Destination.Namespace
+	app.Project = spec.Project
+	app.SyncPolicy = argocd.SyncPolicy{}
+	if policy := spec.SyncPolicy; policy != nil {
+		app.SyncPolicy.SyncOptions = policy.SyncOptions
+		if policy.Automated != nil {
+			app.SyncPolicy.Automated = true
+			app.SyncPolicy.Prune = policy.Automated.Prune
+			app.SyncPolicy.SelfHeal = policy.Automated.SelfHeal
+			app.SyncPolicy.AllowEmpty = policy.Automated.AllowEmpty
+		}
+	}
+}
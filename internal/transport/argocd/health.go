@@ -0,0 +1,74 @@
+package argocd
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/version"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	healthCheckInterval = 30 * time.Second
+	healthCheckTimeout  = 5 * time.Second
+)
+
+// checkHealth pings the server every healthCheckInterval until ctx is done.
+// While the server cannot be reached the cached connections are dropped, so
+// that the next call dials afresh instead of waiting on a dead connection.
+func (a *ArgoCdClient) checkHealth(ctx context.Context) {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	healthy := true
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := a.ping(ctx)
+		if ctx.Err() != nil || errors.Is(err, errClientClosed) {
+			return
+		}
+		if !isUnreachable(err) {
+			if !healthy {
+				a.logger.Infof("ArgoCD server %s is reachable again", a.cfg.Url)
+			}
+			healthy = true
+			continue
+		}
+
+		if healthy {
+			a.logger.Warnf("ArgoCD server %s is unreachable: %v", a.cfg.Url, err)
+		}
+		healthy = false
+		for _, c := range a.unaryConns() {
+			c.drop()
+		}
+	}
+}
+
+// ping asks the server for its version, the cheapest call of the API.
+func (a *ArgoCdClient) ping(ctx context.Context) error {
+	return a.versionConn.callOnce(func(versionClient version.VersionServiceClient) error {
+		ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		defer cancel()
+		_, err := versionClient.Version(ctx, &emptypb.Empty{})
+		return err
+	})
+}
+
+// isUnreachable reports whether a failed ping means the server cannot be
+// reached. A server that answers with an error is up.
+func isUnreachable(err error) bool {
+	if isConnectionError(err) {
+		return true
+	}
+	st, ok := status.FromError(err)
+	return ok && st.Code() == codes.DeadlineExceeded
+}
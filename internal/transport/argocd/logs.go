@@ -0,0 +1,118 @@
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
+)
+
+// StreamPodLogs sends the log lines of ref to onLine until the stream ends or
+// ctx is cancelled. ref is either a Pod or a workload owning pods, in which
+// case ArgoCD merges the logs of all its pods. StreamPodLogs blocks.
+func (a *ArgoCdClient) StreamPodLogs(
+	ctx context.Context,
+	appName string,
+	ref ResourceRef,
+	opts LogOptions,
+	onLine func(LogLine),
+) error {
+	query := &application.ApplicationPodLogsQuery{
+		Name:      &appName,
+		Namespace: &ref.Namespace,
+		Follow:    &opts.Follow,
+		Previous:  &opts.Previous,
+	}
+	if ref.Kind == "Pod" {
+		query.PodName = &ref.Name
+	} else {
+		query.Group = &ref.Group
+		query.Kind = &ref.Kind
+		query.ResourceName = &ref.Name
+	}
+	if opts.Container != "" {
+		query.Container = &opts.Container
+	}
+	if opts.TailLines > 0 {
+		query.TailLines = &opts.TailLines
+	}
+
+	// The stream has a connection of its own, like the watch streams
+	closer, appClient, err := a.appConn.open()
+	if err != nil {
+		return a.logger.Errorf("Error streaming logs of %s/%s: %v", ref.Kind, ref.Name, err)
+	}
+	defer closer.Close()
+	stream, err := appClient.PodLogs(ctx, query)
+	if err != nil {
+		return a.logger.Errorf("Error streaming logs of %s/%s: %v", ref.Kind, ref.Name, err)
+	}
+
+	for {
+		entry, err := stream.Recv()
+		if ctx.Err() != nil || errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return a.logger.Errorf("Error streaming logs of %s/%s: %v", ref.Kind, ref.Name, err)
+		}
+		if entry.GetLast() {
+			return nil
+		}
+
+		line := LogLine{PodName: entry.GetPodName(), Content: entry.GetContent()}
+		if ts, err := time.Parse(time.RFC3339Nano, entry.GetTimeStampStr()); err == nil {
+			line.TimeStamp = ts
+		} else if entry.TimeStamp != nil {
+			line.TimeStamp = entry.TimeStamp.Time
+		}
+		onLine(line)
+	}
+}
+
+type podSpec struct {
+	InitContainers []struct {
+		Name string `json:"name"`
+	} `json:"initContainers"`
+	Containers []struct {
+		Name string `json:"name"`
+	} `json:"containers"`
+}
+
+// GetContainers returns the container names of a Pod, or of the pod template
+// of a workload. Init containers are listed last so the first name is a
+// sensible default.
+func (a *ArgoCdClient) GetContainers(appName string, ref ResourceRef) ([]string, error) {
+	manifest, err := a.GetResourceManifest(appName, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj struct {
+		Spec struct {
+			podSpec
+			Template struct {
+				Spec podSpec `json:"spec"`
+			} `json:"template"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal([]byte(manifest), &obj); err != nil {
+		return nil, a.logger.Errorf("Error decoding %s/%s: %v", ref.Kind, ref.Name, err)
+	}
+
+	spec := obj.Spec.podSpec
+	if ref.Kind != "Pod" {
+		spec = obj.Spec.Template.Spec
+	}
+	var names []string
+	for _, c := range spec.Containers {
+		names = append(names, c.Name)
+	}
+	for _, c := range spec.InitContainers {
+		names = append(names, c.Name)
+	}
+	return names, nil
+}
@@ -1,6 +1,9 @@
 package argocd
 
-import "strings"
+import (
+	"strings"
+	"time"
+)
 
 type Application struct {
 	Name         string `json:"name"`
@@ -9,10 +12,283 @@ type Application struct {
 	SyncCommit   string `json:"syncCommit"`
 	Project      string `json:"project"`
 	LastActivity string `json:"lastActivity"`
+
+	RepoURL        string `json:"repoURL"`
+	Path           string `json:"path"`
+	Chart          string `json:"chart"`
+	TargetRevision string `json:"targetRevision"`
+	// SyncRevision is the full revision the application is compared against
+	SyncRevision  string            `json:"syncRevision"`
+	DestServer    string            `json:"destServer"`
+	DestName      string            `json:"destName"`
+	DestNamespace string            `json:"destNamespace"`
+	SyncPolicy    SyncPolicy        `json:"syncPolicy"`
+	HealthMessage string            `json:"healthMessage"`
+	Conditions    []AppCondition    `json:"conditions"`
+	Operation     *OperationState   `json:"operation"`
+	Images        []string          `json:"images"`
+	Labels        map[string]string `json:"labels"`
+	CreatedAt     time.Time         `json:"createdAt"`
     // Cached lower-cased concatenation for search; not serialized
     SearchIndex  string `json:"-"`
 }
 
+// SyncPolicy is the automated sync configuration of an application.
+type SyncPolicy struct {
+	Automated   bool     `json:"automated"`
+	Prune       bool     `json:"prune"`
+	SelfHeal    bool     `json:"selfHeal"`
+	AllowEmpty  bool     `json:"allowEmpty"`
+	SyncOptions []string `json:"syncOptions"`
+}
+
+// AppSpec is the spec of an application rendered as YAML. ResourceVersion is
+// the version of the application it was read from.
+type AppSpec struct {
+	YAML            string `json:"yaml"`
+	ResourceVersion string `json:"resourceVersion"`
+}
+
+// AppParameters are the source type specific parameters of an application:
+// Helm parameters and value files, or Kustomize images and name affixes.
+type AppParameters struct {
+	SourceType string      `json:"sourceType"`
+	ValueFiles []string    `json:"valueFiles"`
+	Helm       []Parameter `json:"helm"`
+	Images     []Parameter `json:"images"`
+	NamePrefix string      `json:"namePrefix"`
+	NameSuffix string      `json:"nameSuffix"`
+}
+
+// Parameter is a Helm parameter or a Kustomize image. Value is the override
+// when Overridden is set and the default otherwise. ForceString keeps Helm
+// from converting the override to a number or a boolean.
+type Parameter struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	Default     string `json:"default"`
+	Overridden  bool   `json:"overridden"`
+	ForceString bool   `json:"forceString"`
+}
+
+// AppDraft holds the fields of an application to be created.
+type AppDraft struct {
+	Name           string     `json:"name"`
+	Project        string     `json:"project"`
+	RepoURL        string     `json:"repoURL"`
+	Path           string     `json:"path"`
+	Chart          string     `json:"chart"`
+	TargetRevision string     `json:"targetRevision"`
+	DestServer     string     `json:"destServer"`
+	DestNamespace  string     `json:"destNamespace"`
+	SyncPolicy     SyncPolicy `json:"syncPolicy"`
+}
+
+// Project is an ArgoCD project with the sources and destinations it allows.
+// Entries may contain '*' wildcards.
+type Project struct {
+	Name         string               `json:"name"`
+	SourceRepos  []string             `json:"sourceRepos"`
+	Destinations []ProjectDestination `json:"destinations"`
+}
+
+type ProjectDestination struct {
+	Server    string `json:"server"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// Cluster is a destination cluster registered in ArgoCD.
+type Cluster struct {
+	Name   string `json:"name"`
+	Server string `json:"server"`
+}
+
+// Repository is a repository registered in ArgoCD; Type is "git" or "helm".
+type Repository struct {
+	URL  string `json:"url"`
+	Type string `json:"type"`
+}
+
+// AppCondition is an error or warning reported by ArgoCD for an application.
+type AppCondition struct {
+	Type           string    `json:"type"`
+	Message        string    `json:"message"`
+	LastTransition time.Time `json:"lastTransition"`
+}
+
+// OperationState describes the last (or currently running) operation.
+type OperationState struct {
+	Phase      string    `json:"phase"`
+	Message    string    `json:"message"`
+	Revision   string    `json:"revision"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	// Resources holds the per-resource results of a sync operation
+	Resources []ResourceResult `json:"resources"`
+}
+
+// ResourceResult is the outcome of syncing a single resource or hook.
+type ResourceResult struct {
+	Group     string `json:"group"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Status is Synced, SyncFailed, Pruned or PruneSkipped
+	Status    string `json:"status"`
+	Message   string `json:"message"`
+	HookType  string `json:"hookType"`
+	HookPhase string `json:"hookPhase"`
+	SyncPhase string `json:"syncPhase"`
+}
+
+// HistoryEntry is a single deployment from the application history.
+type HistoryEntry struct {
+	ID              int64
+	Revision        string
+	DeployedAt      time.Time
+	DeployStartedAt time.Time
+	// Source is the repository with the path or chart that was deployed
+	Source      string
+	InitiatedBy string
+}
+
+// RevisionMetadata describes a git commit, as far as ArgoCD knows it.
+type RevisionMetadata struct {
+	Author  string
+	Date    time.Time
+	Message string
+	Tags    []string
+}
+
+// EventType mirrors the Kubernetes watch event types sent by ArgoCD streams.
+type EventType string
+
+const (
+	EventAdded    EventType = "ADDED"
+	EventModified EventType = "MODIFIED"
+	EventDeleted  EventType = "DELETED"
+)
+
+// AppEvent is a single incremental change received from the application Watch stream.
+type AppEvent struct {
+	Type EventType
+	App  Application
+}
+
+// SyncOptions mirrors the flags of `argocd app sync`.
+type SyncOptions struct {
+	Revision        string
+	Prune           bool
+	DryRun          bool
+	Force           bool
+	ApplyOnly       bool
+	ServerSideApply bool
+	Replace         bool
+	RetryLimit      int64
+	// Resources limits the sync to the listed resources, like `--resource`
+	Resources []SyncResource
+}
+
+type SyncResource struct {
+	Group     string
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+const (
+	PropagationForeground = "foreground"
+	PropagationBackground = "background"
+)
+
+// DeleteOptions mirrors the flags of `argocd app delete`. Without Cascade the
+// resources of the application are orphaned and PropagationPolicy is ignored.
+type DeleteOptions struct {
+	Cascade           bool
+	PropagationPolicy string
+}
+
+// DeleteResourceOptions mirrors the flags of `argocd app delete-resource`.
+// Force skips the grace period and Orphan leaves dependents in the cluster.
+type DeleteResourceOptions struct {
+	Force  bool
+	Orphan bool
+}
+
+// ResourceDiff holds the live and desired state of a managed resource as YAML.
+// Either side is empty when the resource is missing from the cluster or from git.
+type ResourceDiff struct {
+	Group     string
+	Kind      string
+	Namespace string
+	Name      string
+	// LiveState is the normalized live manifest
+	LiveState string
+	// TargetState is the predicted live manifest after sync, or the target
+	// manifest when ArgoCD cannot predict it
+	TargetState string
+	Modified    bool
+	// SyncWave is taken from the argocd.argoproj.io/sync-wave annotation
+	SyncWave int
+}
+
+// ResourceStatus is the sync state of a managed resource. Missing resources
+// are in git but not in the cluster; extraneous ones are in the cluster but no
+// longer in git, and RequiresPruning.
+type ResourceStatus struct {
+	Group           string
+	Kind            string
+	Namespace       string
+	Name            string
+	Status          string
+	Missing         bool
+	RequiresPruning bool
+}
+
+// ResourceRef identifies a live resource of an application.
+type ResourceRef struct {
+	Group     string
+	Version   string
+	Kind      string
+	Namespace string
+	Name      string
+	UID       string
+}
+
+// Event is a Kubernetes event about an application or one of its resources.
+type Event struct {
+	Type      string
+	Reason    string
+	Message   string
+	Object    string
+	Count     int32
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// ResourceAction is a custom action ArgoCD offers for a resource, such as
+// restarting a Deployment.
+type ResourceAction struct {
+	Name        string
+	DisplayName string
+	Disabled    bool
+}
+
+// LogOptions mirrors the flags of `argocd app logs`.
+type LogOptions struct {
+	Container string
+	Follow    bool
+	Previous  bool
+	TailLines int64
+}
+
+type LogLine struct {
+	PodName   string
+	TimeStamp time.Time
+	Content   string
+}
+
 type Resource struct {
 	Kind         string `json:"kind"`
 	Name         string `json:"name"`
@@ -0,0 +1,177 @@
+package argocd
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/repository"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	repoapiclient "github.com/argoproj/argo-cd/v2/reposerver/apiclient"
+	"sigs.k8s.io/yaml"
+)
+
+// GetAppParameters returns the parameters of the application source with the
+// defaults reported by the repo server and the overrides of the spec.
+func (a *ArgoCdClient) GetAppParameters(appName string) (AppParameters, error) {
+	var app *v1alpha1.Application
+	err := a.withAppClient(func(appClient application.ApplicationServiceClient) (err error) {
+		app, err = appClient.Get(a.ctx, &application.ApplicationQuery{Name: &appName})
+		return err
+	})
+	if err != nil {
+		return AppParameters{}, a.logger.Errorf("Error getting application %s: %v", appName, err)
+	}
+	if app.Spec.HasMultipleSources() {
+		return AppParameters{}, a.logger.Errorf("Parameters of %s: applications with multiple sources are not supported", appName)
+	}
+
+	source := app.Spec.GetSource()
+	var details *repoapiclient.RepoAppDetailsResponse
+	err = a.repoConn.call(func(repoClient repository.RepositoryServiceClient) (err error) {
+		details, err = repoClient.GetAppDetails(a.ctx, &repository.RepoAppDetailsQuery{
+			Source:     &source,
+			AppName:    appName,
+			AppProject: app.Spec.Project,
+		})
+		return err
+	})
+	if err != nil {
+		return AppParameters{}, a.logger.Errorf("Error getting parameters of %s: %v", appName, err)
+	}
+
+	defaults := AppParameters{SourceType: details.Type}
+	if details.Helm != nil {
+		for _, p := range details.Helm.Parameters {
+			defaults.Helm = append(defaults.Helm, Parameter{Name: p.Name, Value: p.Value, Default: p.Value})
+		}
+	}
+	if details.Kustomize != nil {
+		for _, image := range details.Kustomize.Images {
+			defaults.Images = append(defaults.Images, Parameter{Name: imageName(image), Value: image, Default: image})
+		}
+	}
+	return ParametersWithOverrides(defaults, &source), nil
+}
+
+// ParametersWithOverrides applies the overrides of source on top of defaults.
+// Overrides without a default, such as Helm parameters absent from
+// values.yaml, are appended.
+func ParametersWithOverrides(defaults AppParameters, source *v1alpha1.ApplicationSource) AppParameters {
+	params := AppParameters{
+		SourceType: defaults.SourceType,
+		Helm:       append([]Parameter(nil), defaults.Helm...),
+		Images:     append([]Parameter(nil), defaults.Images...),
+	}
+	if source == nil {
+		return params
+	}
+	if params.SourceType == "" {
+		switch {
+		case source.Helm != nil || source.Chart != "":
+			params.SourceType = "Helm"
+		case source.Kustomize != nil:
+			params.SourceType = "Kustomize"
+		}
+	}
+
+	if helm := source.Helm; helm != nil {
+		params.ValueFiles = append([]string(nil), helm.ValueFiles...)
+		for _, p := range helm.Parameters {
+			params.Helm = override(params.Helm, Parameter{Name: p.Name, Value: p.Value, ForceString: p.ForceString})
+		}
+	}
+	if kustomize := source.Kustomize; kustomize != nil {
+		params.NamePrefix = kustomize.NamePrefix
+		params.NameSuffix = kustomize.NameSuffix
+		for _, image := range kustomize.Images {
+			params.Images = override(params.Images, Parameter{Name: imageName(string(image)), Value: string(image)})
+		}
+	}
+	return params
+}
+
+// override sets the value of the parameter named like p, appending p when
+// there is none.
+func override(params []Parameter, p Parameter) []Parameter {
+	p.Overridden = true
+	for i := range params {
+		if params[i].Name == p.Name {
+			p.Default = params[i].Default
+			params[i] = p
+			return params
+		}
+	}
+	return append(params, p)
+}
+
+// SpecWithParameters returns specYAML with the overrides of params written to
+// the source. Parameters that are not overridden are left out of the spec.
+func SpecWithParameters(specYAML string, params AppParameters) (string, error) {
+	var spec v1alpha1.ApplicationSpec
+	if err := yaml.Unmarshal([]byte(specYAML), &spec); err != nil {
+		return "", err
+	}
+	if spec.Source == nil {
+		return "", errors.New("applications with multiple sources are not supported")
+	}
+	source := spec.Source
+
+	switch params.SourceType {
+	case "Helm":
+		if source.Helm == nil {
+			source.Helm = &v1alpha1.ApplicationSourceHelm{}
+		}
+		source.Helm.ValueFiles = params.ValueFiles
+		source.Helm.Parameters = nil
+		for _, p := range params.Helm {
+			if p.Overridden {
+				source.Helm.Parameters = append(source.Helm.Parameters, v1alpha1.HelmParameter{
+					Name:        p.Name,
+					Value:       p.Value,
+					ForceString: p.ForceString,
+				})
+			}
+		}
+		if source.Helm.IsZero() {
+			source.Helm = nil
+		}
+	case "Kustomize":
+		if source.Kustomize == nil {
+			source.Kustomize = &v1alpha1.ApplicationSourceKustomize{}
+		}
+		source.Kustomize.NamePrefix = params.NamePrefix
+		source.Kustomize.NameSuffix = params.NameSuffix
+		source.Kustomize.Images = nil
+		for _, p := range params.Images {
+			if p.Overridden {
+				source.Kustomize.Images = append(source.Kustomize.Images, v1alpha1.KustomizeImage(p.Value))
+			}
+		}
+		if source.Kustomize.IsZero() {
+			source.Kustomize = nil
+		}
+	default:
+		return "", errors.New("source type " + params.SourceType + " has no parameters")
+	}
+
+	out, err := yaml.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// imageName strips the tag and digest of a Kustomize image, and the new name
+// of an override like "nginx=registry/nginx:1.25".
+func imageName(image string) string {
+	if name, _, ok := strings.Cut(image, "="); ok {
+		return name
+	}
+	image, _, _ = strings.Cut(image, "@")
+	// A colon before the last slash belongs to the registry port
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image
+}
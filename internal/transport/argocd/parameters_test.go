@@ -0,0 +1,253 @@
+package argocd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestImageName(t *testing.T) {
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{"nginx", "nginx"},
+		{"nginx:1.25", "nginx"},
+		{"library/nginx:1.25", "library/nginx"},
+		{"registry:5000/nginx", "registry:5000/nginx"},
+		{"registry:5000/nginx:1.25", "registry:5000/nginx"},
+		{"nginx@sha256:0123abcd", "nginx"},
+		{"registry:5000/nginx:1.25@sha256:0123abcd", "registry:5000/nginx"},
+		{"nginx=registry:5000/nginx:1.25", "nginx"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			if got := imageName(tt.image); got != tt.want {
+				t.Errorf("imageName(%q) = %q, want %q", tt.image, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParametersWithOverrides(t *testing.T) {
+	helmDefaults := AppParameters{
+		SourceType: "Helm",
+		Helm: []Parameter{
+			{Name: "replicas", Value: "1", Default: "1"},
+			{Name: "image.tag", Value: "1.0", Default: "1.0"},
+		},
+	}
+	kustomizeDefaults := AppParameters{
+		SourceType: "Kustomize",
+		Images:     []Parameter{{Name: "nginx", Value: "nginx:1.24", Default: "nginx:1.24"}},
+	}
+
+	tests := []struct {
+		name     string
+		defaults AppParameters
+		source   *v1alpha1.ApplicationSource
+		want     AppParameters
+	}{
+		{
+			name:     "no source",
+			defaults: helmDefaults,
+			want:     helmDefaults,
+		},
+		{
+			name:     "helm override keeps the default",
+			defaults: helmDefaults,
+			source: &v1alpha1.ApplicationSource{Helm: &v1alpha1.ApplicationSourceHelm{
+				ValueFiles: []string{"values-prod.yaml"},
+				Parameters: []v1alpha1.HelmParameter{{Name: "image.tag", Value: "2.0", ForceString: true}},
+			}},
+			want: AppParameters{
+				SourceType: "Helm",
+				ValueFiles: []string{"values-prod.yaml"},
+				Helm: []Parameter{
+					{Name: "replicas", Value: "1", Default: "1"},
+					{Name: "image.tag", Value: "2.0", Default: "1.0", Overridden: true, ForceString: true},
+				},
+			},
+		},
+		{
+			name:     "helm override without a default is appended",
+			defaults: helmDefaults,
+			source: &v1alpha1.ApplicationSource{Helm: &v1alpha1.ApplicationSourceHelm{
+				Parameters: []v1alpha1.HelmParameter{{Name: "debug", Value: "true"}},
+			}},
+			want: AppParameters{
+				SourceType: "Helm",
+				Helm: []Parameter{
+					{Name: "replicas", Value: "1", Default: "1"},
+					{Name: "image.tag", Value: "1.0", Default: "1.0"},
+					{Name: "debug", Value: "true", Overridden: true},
+				},
+			},
+		},
+		{
+			name:     "kustomize image renamed by the override",
+			defaults: kustomizeDefaults,
+			source: &v1alpha1.ApplicationSource{Kustomize: &v1alpha1.ApplicationSourceKustomize{
+				NamePrefix: "prod-",
+				Images:     []v1alpha1.KustomizeImage{"nginx=registry:5000/nginx:1.25"},
+			}},
+			want: AppParameters{
+				SourceType: "Kustomize",
+				NamePrefix: "prod-",
+				Images: []Parameter{
+					{Name: "nginx", Value: "nginx=registry:5000/nginx:1.25", Default: "nginx:1.24", Overridden: true},
+				},
+			},
+		},
+		{
+			name: "source type from the source",
+			source: &v1alpha1.ApplicationSource{Chart: "web", Helm: &v1alpha1.ApplicationSourceHelm{
+				Parameters: []v1alpha1.HelmParameter{{Name: "replicas", Value: "3"}},
+			}},
+			want: AppParameters{
+				SourceType: "Helm",
+				Helm:       []Parameter{{Name: "replicas", Value: "3", Overridden: true}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParametersWithOverrides(tt.defaults, tt.source)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParametersWithOverrides() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParametersWithOverridesDoesNotModifyDefaults(t *testing.T) {
+	defaults := AppParameters{
+		SourceType: "Helm",
+		Helm:       []Parameter{{Name: "replicas", Value: "1", Default: "1"}},
+	}
+	ParametersWithOverrides(defaults, &v1alpha1.ApplicationSource{Helm: &v1alpha1.ApplicationSourceHelm{
+		Parameters: []v1alpha1.HelmParameter{{Name: "replicas", Value: "3"}},
+	}})
+	if defaults.Helm[0].Value != "1" || defaults.Helm[0].Overridden {
+		t.Errorf("defaults modified: %+v", defaults.Helm[0])
+	}
+}
+
+func TestSpecWithParameters(t *testing.T) {
+	const spec = `project: default
+source:
+  repoURL: https://git.example.com/api.git
+  path: deploy
+  helm:
+    parameters:
+    - name: replicas
+      value: "2"
+destination:
+  namespace: api
+`
+
+	tests := []struct {
+		name    string
+		spec    string
+		params  AppParameters
+		want    v1alpha1.ApplicationSource
+		wantErr bool
+	}{
+		{
+			name: "helm overrides only",
+			spec: spec,
+			params: AppParameters{
+				SourceType: "Helm",
+				ValueFiles: []string{"values-prod.yaml"},
+				Helm: []Parameter{
+					{Name: "replicas", Value: "1", Default: "1"},
+					{Name: "image.tag", Value: "010", Default: "1.0", Overridden: true, ForceString: true},
+				},
+			},
+			want: v1alpha1.ApplicationSource{
+				RepoURL: "https://git.example.com/api.git",
+				Path:    "deploy",
+				Helm: &v1alpha1.ApplicationSourceHelm{
+					ValueFiles: []string{"values-prod.yaml"},
+					Parameters: []v1alpha1.HelmParameter{{Name: "image.tag", Value: "010", ForceString: true}},
+				},
+			},
+		},
+		{
+			name: "no helm overrides drops the section",
+			spec: spec,
+			params: AppParameters{
+				SourceType: "Helm",
+				Helm:       []Parameter{{Name: "replicas", Value: "1", Default: "1"}},
+			},
+			want: v1alpha1.ApplicationSource{
+				RepoURL: "https://git.example.com/api.git",
+				Path:    "deploy",
+			},
+		},
+		{
+			name: "kustomize",
+			spec: spec,
+			params: AppParameters{
+				SourceType: "Kustomize",
+				NameSuffix: "-prod",
+				Images: []Parameter{
+					{Name: "nginx", Value: "nginx:1.25", Default: "nginx:1.24", Overridden: true},
+					{Name: "redis", Value: "redis:7", Default: "redis:7"},
+				},
+			},
+			want: v1alpha1.ApplicationSource{
+				RepoURL: "https://git.example.com/api.git",
+				Path:    "deploy",
+				Helm: &v1alpha1.ApplicationSourceHelm{
+					Parameters: []v1alpha1.HelmParameter{{Name: "replicas", Value: "2"}},
+				},
+				Kustomize: &v1alpha1.ApplicationSourceKustomize{
+					NameSuffix: "-prod",
+					Images:     []v1alpha1.KustomizeImage{"nginx:1.25"},
+				},
+			},
+		},
+		{
+			name:    "multiple sources",
+			spec:    "sources:\n- repoURL: https://git.example.com/api.git\n",
+			params:  AppParameters{SourceType: "Helm"},
+			wantErr: true,
+		},
+		{
+			name:    "source type without parameters",
+			spec:    spec,
+			params:  AppParameters{SourceType: "Directory"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := SpecWithParameters(tt.spec, tt.params)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("SpecWithParameters() = %q, want an error", out)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got v1alpha1.ApplicationSpec
+			if err := yaml.Unmarshal([]byte(out), &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Project != "default" || got.Destination.Namespace != "api" {
+				t.Errorf("SpecWithParameters() changed the rest of the spec:\n%s", out)
+			}
+			if !reflect.DeepEqual(*got.Source, tt.want) {
+				t.Errorf("source = %+v, want %+v", *got.Source, tt.want)
+			}
+		})
+	}
+}
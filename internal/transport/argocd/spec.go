@@ -0,0 +1,191 @@
+package argocd
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sigs.k8s.io/yaml"
+)
+
+// ErrConflict is returned by UpdateAppSpec when the application was modified
+// after its spec was read.
+var ErrConflict = errors.New("application was modified on the server")
+
+// GetAppSpec returns the spec of the application as YAML.
+func (a *ArgoCdClient) GetAppSpec(appName string) (AppSpec, error) {
+	var app *v1alpha1.Application
+	err := a.withAppClient(func(appClient application.ApplicationServiceClient) (err error) {
+		app, err = appClient.Get(a.ctx, &application.ApplicationQuery{Name: &appName})
+		return err
+	})
+	if err != nil {
+		return AppSpec{}, a.logger.Errorf("Error getting spec of %s: %v", appName, err)
+	}
+	out, err := yaml.Marshal(app.Spec)
+	if err != nil {
+		return AppSpec{}, a.logger.Errorf("Error encoding spec of %s: %v", appName, err)
+	}
+	return AppSpec{YAML: string(out), ResourceVersion: app.ResourceVersion}, nil
+}
+
+// UpdateAppSpec replaces the spec of the application with spec.YAML. It fails
+// with ErrConflict when the application no longer is at spec.ResourceVersion.
+func (a *ArgoCdClient) UpdateAppSpec(appName string, spec AppSpec) error {
+	defer a.forgetManagedResources(appName)
+	parsed, err := parseAppSpec(spec.YAML)
+	if err != nil {
+		return a.logger.Errorf("Invalid spec for %s: %v", appName, err)
+	}
+
+	validate := true
+	err = a.writeWithAppClient(func(appClient application.ApplicationServiceClient) error {
+		app, err := appClient.Get(a.ctx, &application.ApplicationQuery{Name: &appName})
+		if err != nil {
+			return err
+		}
+		if app.ResourceVersion != spec.ResourceVersion {
+			return ErrConflict
+		}
+		app.Spec = *parsed
+		_, err = appClient.Update(a.ctx, &application.ApplicationUpdateRequest{
+			Application: app,
+			Validate:    &validate,
+		})
+		return err
+	})
+	if errors.Is(err, ErrConflict) || isConflict(err) {
+		return a.logger.Errorf("Error updating %s: %w", appName, ErrConflict)
+	}
+	if err != nil {
+		return a.logger.Errorf("Error updating %s: %v", appName, err)
+	}
+	return nil
+}
+
+// ValidateAppSpec checks that specYAML is a well-formed application spec.
+// The server validates the rest when the spec is submitted.
+func ValidateAppSpec(specYAML string) error {
+	_, err := parseAppSpec(specYAML)
+	return err
+}
+
+func parseAppSpec(specYAML string) (*v1alpha1.ApplicationSpec, error) {
+	var spec v1alpha1.ApplicationSpec
+	if err := yaml.UnmarshalStrict([]byte(specYAML), &spec); err != nil {
+		return nil, err
+	}
+	if !spec.HasMultipleSources() && spec.Source == nil {
+		return nil, errors.New("spec has no source")
+	}
+	if spec.Destination.Server == "" && spec.Destination.Name == "" {
+		return nil, errors.New("destination needs a server or a name")
+	}
+	if spec.Project == "" {
+		return nil, errors.New("project is required")
+	}
+	return &spec, nil
+}
+
+// isConflict reports whether the server rejected an update because the
+// object changed in between.
+func isConflict(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	return st.Code() == codes.FailedPrecondition ||
+		strings.Contains(st.Message(), "the object has been modified")
+}
+
+// SetSyncPolicy enables or disables automated sync of the application with
+// a merge patch. Sync options and retry settings are left untouched.
+func (a *ArgoCdClient) SetSyncPolicy(appName string, policy SyncPolicy) error {
+	patch, err := json.Marshal(map[string]interface{}{"spec": syncPolicyPatch(policy)})
+	if err != nil {
+		return a.logger.Errorf("Error encoding sync policy of %s: %v", appName, err)
+	}
+	patchType := "merge"
+	patchStr := string(patch)
+	err = a.writeWithAppClient(func(appClient application.ApplicationServiceClient) error {
+		_, err := appClient.Patch(a.ctx, &application.ApplicationPatchRequest{
+			Name:      &appName,
+			Patch:     &patchStr,
+			PatchType: &patchType,
+		})
+		return err
+	})
+	if err != nil {
+		return a.logger.Errorf("Error updating sync policy of %s: %v", appName, err)
+	}
+	return nil
+}
+
+// SpecWithSyncPolicy returns specYAML as it will look after SetSyncPolicy,
+// so the change can be reviewed before it is applied.
+func SpecWithSyncPolicy(specYAML string, policy SyncPolicy) (string, error) {
+	var spec map[string]interface{}
+	if err := yaml.Unmarshal([]byte(specYAML), &spec); err != nil {
+		return "", err
+	}
+	if spec == nil {
+		spec = make(map[string]interface{})
+	}
+	out, err := yaml.Marshal(mergePatch(spec, syncPolicyPatch(policy)))
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// syncPolicyPatch is the merge patch of the spec setting the automated policy.
+// Disabled flags are removed rather than set to false, matching how the
+// server stores them.
+func syncPolicyPatch(policy SyncPolicy) map[string]interface{} {
+	var automated interface{}
+	if policy.Automated {
+		automated = map[string]interface{}{
+			"prune":      trueOrNil(policy.Prune),
+			"selfHeal":   trueOrNil(policy.SelfHeal),
+			"allowEmpty": trueOrNil(policy.AllowEmpty),
+		}
+	}
+	return map[string]interface{}{
+		"syncPolicy": map[string]interface{}{
+			"automated": automated,
+		},
+	}
+}
+
+func trueOrNil(b bool) interface{} {
+	if b {
+		return true
+	}
+	return nil
+}
+
+// mergePatch applies patch to target following JSON merge patch (RFC 7386):
+// nil values delete keys and nested objects are merged recursively.
+func mergePatch(target, patch map[string]interface{}) map[string]interface{} {
+	for key, value := range patch {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+		patchObj, ok := value.(map[string]interface{})
+		if !ok {
+			target[key] = value
+			continue
+		}
+		targetObj, ok := target[key].(map[string]interface{})
+		if !ok {
+			targetObj = make(map[string]interface{})
+		}
+		target[key] = mergePatch(targetObj, patchObj)
+	}
+	return target
+}
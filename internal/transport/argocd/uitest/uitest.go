@@ -0,0 +1,80 @@
+// Package uitest runs screens against the fake backend in tests.
+package uitest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd/fake"
+	"github.com/Jack200062/ArguTUI/internal/ui"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Start runs a tview application on a simulation screen for the length of the
+// test. The router queues its updates and waits for them, so it is driven
+// from the test goroutine rather than from OnUI.
+func Start(t *testing.T) (*tview.Application, *ui.Router) {
+	t.Helper()
+	screen := tcell.NewSimulationScreen("UTF-8")
+	screen.SetSize(120, 40)
+	app := tview.NewApplication().SetScreen(screen)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := app.Run(); err != nil {
+			t.Errorf("running application: %v", err)
+		}
+	}()
+	t.Cleanup(func() {
+		app.Stop()
+		<-done
+	})
+	return app, ui.NewRouter(app)
+}
+
+// OnUI runs fn on the UI goroutine and waits for it.
+func OnUI(app *tview.Application, fn func()) {
+	done := make(chan struct{})
+	app.QueueUpdate(func() {
+		fn()
+		close(done)
+	})
+	<-done
+}
+
+// Eventually fails the test unless cond, run on the UI goroutine, becomes
+// true within a second.
+func Eventually(t *testing.T, app *tview.Application, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		var ok bool
+		OnUI(app, func() { ok = cond() })
+		if ok {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within a second")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// WaitForCall fails the test unless b records a call to method within a
+// second.
+func WaitForCall(t *testing.T, b *fake.Backend, method string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		for _, call := range b.Calls() {
+			if call.Method == method {
+				return
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%s not called within a second", method)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
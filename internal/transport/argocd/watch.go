@@ -0,0 +1,170 @@
+package argocd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+)
+
+const (
+	watchMinBackoff = 1 * time.Second
+	watchMaxBackoff = 30 * time.Second
+)
+
+// WatchStatusHandler is notified whenever a watch stream connects or drops.
+// A nil error means the stream is connected.
+type WatchStatusHandler func(connected bool, err error)
+
+// WatchApps subscribes to the application Watch stream and calls onEvent for
+// every add/modify/delete. The stream is re-established with exponential
+// backoff until ctx is cancelled; onStatus lets callers fall back to polling
+// while the stream is unavailable. WatchApps blocks, run it in a goroutine.
+//
+// Every stream session dials a connection of its own, closed when the session
+// ends, so it does not depend on the connection shared by unary calls.
+func (a *ArgoCdClient) WatchApps(ctx context.Context, onEvent func(AppEvent), onStatus WatchStatusHandler) {
+	a.watchWithBackoff(ctx, "applications", onStatus, func(connected func()) error {
+		closer, appClient, err := a.appConn.open()
+		if err != nil {
+			return err
+		}
+		defer closer.Close()
+
+		stream, err := appClient.Watch(ctx, &application.ApplicationQuery{})
+		if err != nil {
+			return err
+		}
+		connected()
+
+		for {
+			ev, err := stream.Recv()
+			if err != nil {
+				return err
+			}
+
+			eventType := EventType(ev.Type)
+			switch eventType {
+			case EventAdded, EventModified, EventDeleted:
+				onEvent(AppEvent{Type: eventType, App: toApplication(&ev.Application)})
+			}
+		}
+	})
+}
+
+// WatchApp is WatchApps limited to a single application. onApp receives the
+// full application after every change; deletion is not reported.
+func (a *ArgoCdClient) WatchApp(ctx context.Context, appName string, onApp func(Application), onStatus WatchStatusHandler) {
+	a.watchWithBackoff(ctx, "application "+appName, onStatus, func(connected func()) error {
+		closer, appClient, err := a.appConn.open()
+		if err != nil {
+			return err
+		}
+		defer closer.Close()
+
+		stream, err := appClient.Watch(ctx, &application.ApplicationQuery{Name: &appName})
+		if err != nil {
+			return err
+		}
+		connected()
+
+		for {
+			ev, err := stream.Recv()
+			if err != nil {
+				return err
+			}
+			if EventType(ev.Type) != EventDeleted {
+				onApp(toApplication(&ev.Application))
+			}
+		}
+	})
+}
+
+// WatchResourceTree subscribes to the resource tree stream of a single
+// application. Every message carries the complete tree, so onTree receives
+// a full snapshot each time something in the application changes.
+func (a *ArgoCdClient) WatchResourceTree(
+	ctx context.Context,
+	appName string,
+	onTree func(*v1alpha1.ApplicationTree),
+	onStatus WatchStatusHandler,
+) {
+	a.watchWithBackoff(ctx, "resource tree of "+appName, onStatus, func(connected func()) error {
+		closer, appClient, err := a.appConn.open()
+		if err != nil {
+			return err
+		}
+		defer closer.Close()
+
+		stream, err := appClient.WatchResourceTree(ctx, &application.ResourcesQuery{
+			ApplicationName: &appName,
+		})
+		if err != nil {
+			return err
+		}
+		connected()
+
+		for {
+			tree, err := stream.Recv()
+			if err != nil {
+				return err
+			}
+			onTree(tree)
+		}
+	})
+}
+
+// watchWithBackoff runs a single stream session repeatedly until ctx is done.
+// The backoff is only reset after a session stayed up for a while, so a
+// server that accepts the stream and immediately drops it is not hammered.
+func (a *ArgoCdClient) watchWithBackoff(
+	ctx context.Context,
+	name string,
+	onStatus WatchStatusHandler,
+	session func(connected func()) error,
+) {
+	backoff := watchMinBackoff
+	// Only the first failure of an outage is logged; the log shares the
+	// terminal with the UI, which shows the stream status itself
+	logged := false
+	for {
+		started := time.Now()
+		err := session(func() {
+			logged = false
+			if onStatus != nil {
+				onStatus(true, nil)
+			}
+		})
+
+		if ctx.Err() != nil || errors.Is(err, errClientClosed) {
+			return
+		}
+		if time.Since(started) > watchMaxBackoff {
+			backoff = watchMinBackoff
+		}
+		if err == nil || errors.Is(err, io.EOF) {
+			err = errors.New("stream closed by server")
+		}
+		if !logged {
+			a.logger.Warnf("Watch stream for %s interrupted, reconnecting: %v", name, err)
+			logged = true
+		}
+		if onStatus != nil {
+			onStatus(false, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > watchMaxBackoff {
+			backoff = watchMaxBackoff
+		}
+	}
+}
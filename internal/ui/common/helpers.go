@@ -28,7 +28,7 @@ func ColorForHealthStatus(status string) tcell.Color {
 func RowColorForStatuses(healthStatus, syncStatus string) tcell.Color {
 	if strings.ToLower(healthStatus) != "healthy" {
 		return ColorForHealthStatus(healthStatus)
-	} else if strings.ToLower(syncStatus) != "synced" {
+	} else if syncStatus != "" && strings.ToLower(syncStatus) != "synced" {
 		return tcell.ColorOrange
 	}
 	return ColorForHealthStatus("healthy")
@@ -9,6 +9,8 @@ import (
 type InstanceInfo struct {
 	URL  string
 	Name string
+	// Production instances require typing the app name to confirm deletion
+	Production bool
 
 	AppName      string
 	HealthStatus string
@@ -0,0 +1,107 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// deletionChoices are the options of `argocd app delete`, in the order they
+// are offered. Foreground cascade is the CLI default.
+var deletionChoices = []struct {
+	label string
+	opts  argocd.DeleteOptions
+}{
+	{"Cascade (foreground)", argocd.DeleteOptions{Cascade: true, PropagationPolicy: argocd.PropagationForeground}},
+	{"Cascade (background)", argocd.DeleteOptions{Cascade: true, PropagationPolicy: argocd.PropagationBackground}},
+	{"Non-cascade (orphan resources)", argocd.DeleteOptions{}},
+}
+
+// DeleteAppModal builds a centered form choosing how the application is
+// deleted. With confirmName the app name must be typed before deleting.
+func DeleteAppModal(
+	appName string,
+	confirmName bool,
+	onSubmit func(argocd.DeleteOptions),
+	onCancel func(),
+) tview.Primitive {
+	textColor := tcell.NewHexColor(0x00bebe)
+	backgroundColor := tcell.NewHexColor(0x000000)
+	fieldBgColor := tcell.NewHexColor(0x1a1a1a)
+	buttonBgColor := tcell.NewHexColor(0x017be9)
+
+	form := tview.NewForm().
+		SetFieldBackgroundColor(fieldBgColor).
+		SetFieldTextColor(tcell.ColorWhite).
+		SetLabelColor(textColor).
+		SetButtonBackgroundColor(buttonBgColor).
+		SetButtonTextColor(tcell.ColorWhite)
+	form.SetBackgroundColor(backgroundColor)
+
+	hint := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter)
+	hint.SetBackgroundColor(backgroundColor)
+
+	labels := make([]string, len(deletionChoices))
+	for i, choice := range deletionChoices {
+		labels[i] = choice.label
+	}
+	selected := 0
+	form.AddDropDown("Deletion", labels, selected, func(_ string, index int) {
+		selected = index
+	})
+
+	typed := ""
+	if confirmName {
+		hint.SetText(fmt.Sprintf("[yellow]Production instance: type [white]%s[yellow] to confirm", appName))
+		form.AddInputField("App name", "", 30, nil, func(text string) {
+			typed = text
+		})
+	}
+
+	form.AddButton("Delete", func() {
+		if confirmName && typed != appName {
+			hint.SetText(fmt.Sprintf("[red]Name does not match, type [white]%s[red] to confirm", appName))
+			return
+		}
+		if onSubmit != nil {
+			onSubmit(deletionChoices[selected].opts)
+		}
+	})
+	form.AddButton("Cancel", func() {
+		if onCancel != nil {
+			onCancel()
+		}
+	})
+	form.SetButtonsAlign(tview.AlignCenter)
+
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			if onCancel != nil {
+				onCancel()
+			}
+			return nil
+		}
+		return event
+	})
+
+	height := 9
+	if confirmName {
+		height = 11
+	}
+	layout := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(form, 0, 1, true).
+		AddItem(hint, 1, 0, false)
+	layout.SetBackgroundColor(backgroundColor).
+		SetBorderColor(tcell.ColorDarkRed).
+		SetBorder(true).
+		SetTitle(fmt.Sprintf(" Delete %s ", appName)).
+		SetTitleAlign(tview.AlignCenter).
+		SetTitleColor(tcell.ColorWhite)
+
+	return Centered(layout, 60, height)
+}
@@ -0,0 +1,85 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// DeleteResourceModal builds a centered confirmation naming the resource,
+// with the force and orphan options of `argocd app delete-resource`.
+func DeleteResourceModal(
+	ref argocd.ResourceRef,
+	onSubmit func(argocd.DeleteResourceOptions),
+	onCancel func(),
+) tview.Primitive {
+	textColor := tcell.NewHexColor(0x00bebe)
+	backgroundColor := tcell.NewHexColor(0x000000)
+	fieldBgColor := tcell.NewHexColor(0x1a1a1a)
+	buttonBgColor := tcell.NewHexColor(0x017be9)
+
+	form := tview.NewForm().
+		SetFieldBackgroundColor(fieldBgColor).
+		SetFieldTextColor(tcell.ColorWhite).
+		SetLabelColor(textColor).
+		SetButtonBackgroundColor(buttonBgColor).
+		SetButtonTextColor(tcell.ColorWhite)
+	form.SetBackgroundColor(backgroundColor)
+
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = "(cluster)"
+	}
+	target := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText(fmt.Sprintf("Delete [white]%s[-] [white]%s/%s[-]?", ref.Kind, namespace, ref.Name))
+	target.SetBackgroundColor(backgroundColor).SetBorderPadding(1, 0, 1, 1)
+	target.SetTextColor(textColor)
+
+	var opts argocd.DeleteResourceOptions
+	form.AddCheckbox("Force (no grace period)", false, func(checked bool) {
+		opts.Force = checked
+	})
+	form.AddCheckbox("Orphan dependents", false, func(checked bool) {
+		opts.Orphan = checked
+	})
+
+	form.AddButton("Delete", func() {
+		if onSubmit != nil {
+			onSubmit(opts)
+		}
+	})
+	form.AddButton("Cancel", func() {
+		if onCancel != nil {
+			onCancel()
+		}
+	})
+	form.SetButtonsAlign(tview.AlignCenter)
+
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			if onCancel != nil {
+				onCancel()
+			}
+			return nil
+		}
+		return event
+	})
+
+	layout := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(target, 2, 0, false).
+		AddItem(form, 0, 1, true)
+	layout.SetBackgroundColor(backgroundColor).
+		SetBorderColor(tcell.ColorDarkRed).
+		SetBorder(true).
+		SetTitle(" Delete resource ").
+		SetTitleAlign(tview.AlignCenter).
+		SetTitleColor(tcell.ColorWhite)
+
+	width := max(60, len(ref.Kind)+len(namespace)+len(ref.Name)+14)
+	return Centered(layout, width, 12)
+}
@@ -0,0 +1,82 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// ColorizeDiffLine adds color tags to a line of a unified diff.
+func ColorizeDiffLine(line string) string {
+	escaped := tview.Escape(line)
+	switch {
+	case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		return "[white::b]" + escaped + "[-:-:-]"
+	case strings.HasPrefix(line, "@@"):
+		return "[#00bebe]" + escaped + "[-]"
+	case strings.HasPrefix(line, "+"):
+		return "[green]" + escaped + "[-]"
+	case strings.HasPrefix(line, "-"):
+		return "[red]" + escaped + "[-]"
+	default:
+		return escaped
+	}
+}
+
+// DiffConfirmModal shows a unified diff and asks whether to apply it.
+// 'y' confirms, 'n' or Esc cancels, the arrow keys scroll.
+func DiffConfirmModal(title, unified string, onConfirm, onCancel func()) tview.Primitive {
+	textColor := tcell.NewHexColor(0x00bebe)
+	backgroundColor := tcell.NewHexColor(0x000000)
+	borderColor := tcell.NewHexColor(0x63a0bf)
+
+	var body strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(unified, "\n"), "\n") {
+		body.WriteString(ColorizeDiffLine(line))
+		body.WriteString("\n")
+	}
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(false).
+		SetScrollable(true).
+		SetText(body.String())
+	view.SetBackgroundColor(backgroundColor)
+
+	footer := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetText("[#017be9]y[-] apply   [#017be9]n/Esc[-] cancel")
+	footer.SetBackgroundColor(backgroundColor)
+
+	layout := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(view, 0, 1, true).
+		AddItem(footer, 1, 0, false)
+	layout.SetBackgroundColor(backgroundColor)
+	layout.SetBorder(true).
+		SetBorderColor(borderColor).
+		SetTitle(" " + title + " ").
+		SetTitleAlign(tview.AlignCenter).
+		SetTitleColor(textColor)
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Rune() == 'y':
+			if onConfirm != nil {
+				onConfirm()
+			}
+			return nil
+		case event.Rune() == 'n', event.Key() == tcell.KeyEscape:
+			if onCancel != nil {
+				onCancel()
+			}
+			return nil
+		}
+		return event
+	})
+
+	height := strings.Count(body.String(), "\n") + 3
+	return Centered(layout, 100, min(max(height, 8), 30))
+}
@@ -89,12 +89,13 @@ func (h *HelpView) GenerateSections() []HelpSection {
 		{
 			Title: "GENERAL",
 			Shortcuts: map[string]string{
-				"q": "Close TUI application",
-				"?": "Show/hide this help",
-				"b": "Go back to previous screen",
-				"/": "Search in current view",
-				":": "Alternative search key",
-				"I": "Return to instance selection",
+				"q":      "Close TUI application",
+				"?":      "Show/hide this help",
+				"b":      "Go back to previous screen",
+				"/":      "Search in current view",
+				":":      "Alternative search key",
+				"Ctrl+T": "Cycle search mode (fuzzy, substring, regex)",
+				"I":      "Return to instance selection",
 			},
 		},
 		{
@@ -102,8 +103,14 @@ func (h *HelpView) GenerateSections() []HelpSection {
 			Shortcuts: map[string]string{
 				"R":     "Refresh all applications",
 				"r":     "Refresh selected application",
-				"S":     "Sync selected application",
-				"D":     "Delete selected application",
+				"S":     "Sync selected application (with options)",
+				"n":     "Create application (wizard)",
+				"D":     "Delete selected application (cascade or orphan)",
+				"A":     "Toggle automated sync, self heal and prune",
+				"e":     "Edit application spec in $EDITOR",
+				"m":     "Edit Helm/Kustomize parameters",
+				"i":     "Show application details",
+				"w":     "Watch sync operation progress",
 				"↑/↓":   "Navigate applications list",
 				"Enter": "Open application resources",
 			},
@@ -118,16 +125,78 @@ func (h *HelpView) GenerateSections() []HelpSection {
 				"s":    "Toggle Synced filter",
 				"o, O": "Toggle OutOfSync filter",
 				"c, C": "Clear all filters",
+				"Tab":  "Complete search query field or value",
 			},
 		},
 		{
 			Title: "RESOURCES",
 			Shortcuts: map[string]string{
-				"t": "Toggle resource tree expansion",
-				"d": "Filter by Deployments",
-				"s": "Filter by Services",
-				"i": "Filter by Ingress",
-				"c": "Filter by ConfigMaps",
+				"t":     "Toggle resource tree expansion",
+				"S":     "Sync application or marked resources",
+				"Space": "Mark/unmark resource for sync",
+				"M":     "Mark all OutOfSync resources",
+				"U":     "Clear marked resources",
+				"v":     "Diff live vs desired state of resource",
+				"V":     "Diff all OutOfSync resources",
+				"l":     "Stream logs of Pod or workload",
+				"y":     "Show live manifest as YAML",
+				"a":     "Run resource action (restart, scale, ...)",
+				"x":     "Delete resource (force/orphan)",
+				"O":     "Show/hide orphaned resources",
+				"e":     "Show events of resource",
+				"E":     "Show events of application",
+				"w":     "Watch sync operation progress",
+				"d":     "Filter by Deployments",
+				"s":     "Filter by Services",
+				"i":     "Filter by Ingress",
+				"c":     "Filter by ConfigMaps",
+				"f":     "Filter by kind, namespace, health, sync (multi-select)",
+			},
+		},
+		{
+			Title: "DETAILS & HISTORY",
+			Shortcuts: map[string]string{
+				"h": "Open deployment history (details screen)",
+				"p": "Open parameters (details screen)",
+				"R": "Roll back to selected deployment (history screen)",
+				"r": "Reload",
+			},
+		},
+		{
+			Title: "PARAMETERS",
+			Shortcuts: map[string]string{
+				"Enter": "Edit selected parameter",
+				"n":     "Add Helm parameter",
+				"x":     "Reset parameter to default",
+				"s":     "Save overrides (shows spec diff)",
+				"r":     "Reload and discard changes",
+			},
+		},
+		{
+			Title: "OPERATION",
+			Shortcuts: map[string]string{
+				"T": "Terminate running operation",
+				"r": "Reload sync waves",
+			},
+		},
+		{
+			Title: "MANIFEST",
+			Shortcuts: map[string]string{
+				"m": "Show/hide managedFields",
+				"x": "Show/hide status",
+				"s": "Save manifest to file",
+				"r": "Reload manifest",
+			},
+		},
+		{
+			Title: "LOGS",
+			Shortcuts: map[string]string{
+				"f": "Follow/pause the stream",
+				"c": "Pick container",
+				"/": "Grep log lines",
+				"t": "Toggle timestamps",
+				"P": "Toggle previous container logs",
+				"s": "Save buffer to file",
 			},
 		},
 	}
@@ -0,0 +1,56 @@
+package components
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// SaveFileModal asks for the path to save a buffer to, prefilled with defaultPath.
+func SaveFileModal(title, defaultPath string, onSave func(path string), onCancel func()) tview.Primitive {
+	textColor := tcell.NewHexColor(0x00bebe)
+	backgroundColor := tcell.NewHexColor(0x000000)
+	borderColor := tcell.NewHexColor(0x63a0bf)
+	fieldBgColor := tcell.NewHexColor(0x1a1a1a)
+	buttonBgColor := tcell.NewHexColor(0x017be9)
+
+	path := defaultPath
+	form := tview.NewForm().
+		SetFieldBackgroundColor(fieldBgColor).
+		SetFieldTextColor(tcell.ColorWhite).
+		SetLabelColor(textColor).
+		SetButtonBackgroundColor(buttonBgColor).
+		SetButtonTextColor(tcell.ColorWhite)
+	form.SetBackgroundColor(backgroundColor).
+		SetBorderColor(borderColor).
+		SetBorder(true).
+		SetTitle(" " + title + " ").
+		SetTitleAlign(tview.AlignCenter).
+		SetTitleColor(textColor)
+
+	form.AddInputField("Path", defaultPath, 50, nil, func(text string) {
+		path = text
+	})
+	form.AddButton("Save", func() {
+		if path != "" && onSave != nil {
+			onSave(path)
+		}
+	})
+	form.AddButton("Cancel", func() {
+		if onCancel != nil {
+			onCancel()
+		}
+	})
+	form.SetButtonsAlign(tview.AlignCenter)
+
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			if onCancel != nil {
+				onCancel()
+			}
+			return nil
+		}
+		return event
+	})
+
+	return Centered(form, 62, 7)
+}
@@ -7,6 +7,9 @@ import (
 
 type SimpleSearchBar struct {
 	InputField *tview.InputField
+	Mode       SearchMode
+
+	label string
 }
 
 func NewSimpleSearchBar(label string, fieldWidth int) *SimpleSearchBar {
@@ -14,5 +17,26 @@ func NewSimpleSearchBar(label string, fieldWidth int) *SimpleSearchBar {
 		SetLabel(label).
 		SetFieldWidth(fieldWidth).
 		SetFieldStyle(tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorBlack))
-	return &SimpleSearchBar{InputField: input}
+	return &SimpleSearchBar{InputField: input, label: label}
+}
+
+// EnableModes shows the search mode in the prompt and cycles it with Ctrl+T,
+// calling onChange with the new mode.
+func (b *SimpleSearchBar) EnableModes(onChange func(SearchMode)) {
+	b.updateLabel()
+	b.InputField.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() != tcell.KeyCtrlT {
+			return event
+		}
+		b.Mode = b.Mode.Next()
+		b.updateLabel()
+		if onChange != nil {
+			onChange(b.Mode)
+		}
+		return nil
+	})
+}
+
+func (b *SimpleSearchBar) updateLabel() {
+	b.InputField.SetLabel(b.label + "[gray]" + b.Mode.String() + " ›[-] ")
 }
@@ -1,41 +1,141 @@
 package components
 
 import (
-	"fmt"
-	"reflect"
+	"regexp"
 	"strings"
 
+	"github.com/rivo/tview"
 	"github.com/sahilm/fuzzy"
 )
 
-func CompositeContentReflect(item interface{}) string {
-	v := reflect.ValueOf(item)
-	if v.Kind() == reflect.Ptr {
-		v = v.Elem()
+// SearchMode is how a search pattern matches text.
+type SearchMode int
+
+const (
+	SearchFuzzy SearchMode = iota
+	SearchSubstring
+	SearchRegex
+)
+
+func (m SearchMode) String() string {
+	switch m {
+	case SearchSubstring:
+		return "substring"
+	case SearchRegex:
+		return "regex"
 	}
-	var parts []string
-	for i := 0; i < v.NumField(); i++ {
-		parts = append(parts, fmt.Sprintf("%v", v.Field(i).Interface()))
+	return "fuzzy"
+}
+
+// Next is the mode after m, cycling fuzzy, substring and regex.
+func (m SearchMode) Next() SearchMode {
+	return (m + 1) % 3
+}
+
+// Matcher matches text against a pattern, ignoring case.
+type Matcher struct {
+	mode    SearchMode
+	pattern string
+	re      *regexp.Regexp
+}
+
+// NewMatcher returns a matcher of pattern in mode; it fails when a regex
+// pattern does not compile.
+func NewMatcher(mode SearchMode, pattern string) (*Matcher, error) {
+	m := &Matcher{mode: mode, pattern: strings.ToLower(pattern)}
+	if mode == SearchRegex {
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, err
+		}
+		m.re = re
 	}
-	return strings.Join(parts, " ")
+	return m, nil
 }
 
-func FuzzyFilter(query string, items []interface{}) []int {
-	if query == "" {
-		indices := make([]int, len(items))
-		for i := range items {
-			indices[i] = i
+// Match reports whether text matches, with the byte offsets of the matched
+// characters. Fuzzy matches are scored, higher is better; the other modes
+// score 0.
+func (m *Matcher) Match(text string) (score int, indexes []int, ok bool) {
+	switch m.mode {
+	case SearchSubstring:
+		start := strings.Index(strings.ToLower(text), m.pattern)
+		if start < 0 {
+			return 0, nil, false
+		}
+		return 0, span(start, start+len(m.pattern)), true
+	case SearchRegex:
+		loc := m.re.FindStringIndex(text)
+		if loc == nil {
+			return 0, nil, false
 		}
-		return indices
+		return 0, span(loc[0], loc[1]), true
 	}
-	composites := make([]string, len(items))
-	for i, item := range items {
-		composites[i] = strings.ToLower(CompositeContentReflect(item))
+	matches := fuzzy.Find(m.pattern, []string{text})
+	if len(matches) == 0 {
+		return 0, nil, false
+	}
+	return matches[0].Score, matches[0].MatchedIndexes, true
+}
+
+func span(start, end int) []int {
+	indexes := make([]int, 0, end-start)
+	for i := start; i < end; i++ {
+		indexes = append(indexes, i)
 	}
-	matches := fuzzy.Find(strings.ToLower(query), composites)
-	indices := make([]int, len(matches))
-	for i, m := range matches {
-		indices[i] = m.Index
+	return indexes
+}
+
+// SplitMatches maps offsets in the fields joined by single spaces to offsets
+// in each field. Offsets past the last field are dropped.
+func SplitMatches(indexes []int, fields ...string) [][]int {
+	split := make([][]int, len(fields))
+	if len(indexes) == 0 {
+		return split
+	}
+	start := 0
+	for i, field := range fields {
+		end := start + len(field)
+		for _, index := range indexes {
+			if index >= start && index < end {
+				split[i] = append(split[i], index-start)
+			}
+		}
+		start = end + 1
+	}
+	return split
+}
+
+// Highlight escapes text for tview and underlines the bytes at indexes in
+// bold, keeping the colors of the cell.
+func Highlight(text string, indexes []int) string {
+	if len(indexes) == 0 {
+		return tview.Escape(text)
+	}
+	marked := make(map[int]bool, len(indexes))
+	for _, index := range indexes {
+		marked[index] = true
+	}
+	var b strings.Builder
+	inside := false
+	runStart := 0
+	flush := func(end int) {
+		if end == runStart {
+			return
+		}
+		if inside {
+			b.WriteString("[::bu]" + tview.Escape(text[runStart:end]) + "[::-]")
+		} else {
+			b.WriteString(tview.Escape(text[runStart:end]))
+		}
+		runStart = end
+	}
+	for i := range text {
+		if marked[i] != inside {
+			flush(i)
+			inside = marked[i]
+		}
 	}
-	return indices
+	flush(len(text))
+	return b.String()
 }
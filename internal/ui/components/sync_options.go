@@ -0,0 +1,144 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+var (
+	lastSyncOptionsMu sync.Mutex
+	lastSyncOptions   = make(map[string]argocd.SyncOptions)
+)
+
+// LastSyncOptions returns the options last used on the given instance.
+// The target revision and resource selection are app specific and are never remembered.
+func LastSyncOptions(instanceName string) argocd.SyncOptions {
+	lastSyncOptionsMu.Lock()
+	defer lastSyncOptionsMu.Unlock()
+	return lastSyncOptions[instanceName]
+}
+
+func RememberSyncOptions(instanceName string, opts argocd.SyncOptions) {
+	lastSyncOptionsMu.Lock()
+	defer lastSyncOptionsMu.Unlock()
+	opts.Revision = ""
+	opts.Resources = nil
+	lastSyncOptions[instanceName] = opts
+}
+
+// SyncOptionsModal builds a centered form for the flags of `argocd app sync`.
+func SyncOptionsModal(
+	appName string,
+	opts argocd.SyncOptions,
+	onSubmit func(argocd.SyncOptions),
+	onCancel func(),
+) tview.Primitive {
+	textColor := tcell.NewHexColor(0x00bebe)
+	backgroundColor := tcell.NewHexColor(0x000000)
+	borderColor := tcell.NewHexColor(0x63a0bf)
+	fieldBgColor := tcell.NewHexColor(0x1a1a1a)
+	buttonBgColor := tcell.NewHexColor(0x017be9)
+
+	form := tview.NewForm().
+		SetFieldBackgroundColor(fieldBgColor).
+		SetFieldTextColor(tcell.ColorWhite).
+		SetLabelColor(textColor).
+		SetButtonBackgroundColor(buttonBgColor).
+		SetButtonTextColor(tcell.ColorWhite)
+	form.SetBackgroundColor(backgroundColor).
+		SetBorderColor(borderColor).
+		SetBorder(true).
+		SetTitle(syncTitle(appName, opts)).
+		SetTitleAlign(tview.AlignCenter).
+		SetTitleColor(textColor)
+
+	form.AddInputField("Revision", opts.Revision, 30, nil, func(text string) {
+		opts.Revision = text
+	})
+	form.AddCheckbox("Prune", opts.Prune, func(checked bool) {
+		opts.Prune = checked
+	})
+	form.AddCheckbox("Dry run", opts.DryRun, func(checked bool) {
+		opts.DryRun = checked
+	})
+	form.AddCheckbox("Force", opts.Force, func(checked bool) {
+		opts.Force = checked
+	})
+	form.AddCheckbox("Apply only (skip hooks)", opts.ApplyOnly, func(checked bool) {
+		opts.ApplyOnly = checked
+	})
+	form.AddCheckbox("Server-side apply", opts.ServerSideApply, func(checked bool) {
+		opts.ServerSideApply = checked
+	})
+	form.AddCheckbox("Replace", opts.Replace, func(checked bool) {
+		opts.Replace = checked
+	})
+
+	retryText := ""
+	if opts.RetryLimit > 0 {
+		retryText = strconv.FormatInt(opts.RetryLimit, 10)
+	}
+	form.AddInputField("Retry limit", retryText, 5, tview.InputFieldInteger, func(text string) {
+		limit, err := strconv.ParseInt(text, 10, 64)
+		if err != nil || limit < 0 {
+			limit = 0
+		}
+		opts.RetryLimit = limit
+	})
+
+	form.AddButton("Sync", func() {
+		if onSubmit != nil {
+			onSubmit(opts)
+		}
+	})
+	form.AddButton("Cancel", func() {
+		if onCancel != nil {
+			onCancel()
+		}
+	})
+	form.SetButtonsAlign(tview.AlignCenter)
+
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			if onCancel != nil {
+				onCancel()
+			}
+			return nil
+		}
+		return event
+	})
+
+	return Centered(form, 50, 21)
+}
+
+func syncTitle(appName string, opts argocd.SyncOptions) string {
+	switch len(opts.Resources) {
+	case 0:
+		return fmt.Sprintf(" Sync %s ", appName)
+	case 1:
+		res := opts.Resources[0]
+		return fmt.Sprintf(" Sync %s/%s in %s ", res.Kind, res.Name, appName)
+	default:
+		return fmt.Sprintf(" Sync %d resources in %s ", len(opts.Resources), appName)
+	}
+}
+
+// Centered places p in the middle of the screen with a fixed size.
+func Centered(p tview.Primitive, width, height int) tview.Primitive {
+	return tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(
+			tview.NewFlex().
+				SetDirection(tview.FlexColumn).
+				AddItem(nil, 0, 1, false).
+				AddItem(p, width, 0, true).
+				AddItem(nil, 0, 1, false),
+			height, 0, true).
+		AddItem(nil, 0, 1, false)
+}
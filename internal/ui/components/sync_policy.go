@@ -0,0 +1,75 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// SyncPolicyModal builds a centered form toggling automated sync, self heal
+// and prune, prefilled with the current policy.
+func SyncPolicyModal(
+	appName string,
+	policy argocd.SyncPolicy,
+	onSubmit func(argocd.SyncPolicy),
+	onCancel func(),
+) tview.Primitive {
+	textColor := tcell.NewHexColor(0x00bebe)
+	backgroundColor := tcell.NewHexColor(0x000000)
+	borderColor := tcell.NewHexColor(0x63a0bf)
+	fieldBgColor := tcell.NewHexColor(0x1a1a1a)
+	buttonBgColor := tcell.NewHexColor(0x017be9)
+
+	form := tview.NewForm().
+		SetFieldBackgroundColor(fieldBgColor).
+		SetFieldTextColor(tcell.ColorWhite).
+		SetLabelColor(textColor).
+		SetButtonBackgroundColor(buttonBgColor).
+		SetButtonTextColor(tcell.ColorWhite)
+	form.SetBackgroundColor(backgroundColor).
+		SetBorderColor(borderColor).
+		SetBorder(true).
+		SetTitle(fmt.Sprintf(" Sync policy of %s ", appName)).
+		SetTitleAlign(tview.AlignCenter).
+		SetTitleColor(textColor)
+
+	// Prune and self heal only apply to automated sync
+	form.AddCheckbox("Automated sync", policy.Automated, func(checked bool) {
+		policy.Automated = checked
+	})
+	form.AddCheckbox("Self heal", policy.SelfHeal, func(checked bool) {
+		policy.SelfHeal = checked
+	})
+	form.AddCheckbox("Prune", policy.Prune, func(checked bool) {
+		policy.Prune = checked
+	})
+
+	form.AddButton("Review", func() {
+		if onSubmit != nil {
+			if !policy.Automated {
+				policy.Prune, policy.SelfHeal = false, false
+			}
+			onSubmit(policy)
+		}
+	})
+	form.AddButton("Cancel", func() {
+		if onCancel != nil {
+			onCancel()
+		}
+	})
+	form.SetButtonsAlign(tview.AlignCenter)
+
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			if onCancel != nil {
+				onCancel()
+			}
+			return nil
+		}
+		return event
+	})
+
+	return Centered(form, 50, 11)
+}
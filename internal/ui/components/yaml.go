@@ -0,0 +1,81 @@
+package components
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+var (
+	yamlKeyPattern    = regexp.MustCompile(`^(\s*)(- )?([^\s#'"][^:]*|'[^']*'|"[^"]*"):(\s+|$)(.*)$`)
+	yamlListPattern   = regexp.MustCompile(`^(\s*)- (.*)$`)
+	yamlScalarPattern = regexp.MustCompile(`^(true|false|null|~|-?[0-9][0-9._eE+-]*)$`)
+)
+
+// HighlightYAML adds tview color tags to a YAML document: keys, strings,
+// scalars and comments get distinct colors. The input is escaped.
+func HighlightYAML(text string) string {
+	lines := strings.Split(text, "\n")
+	// blockIndent is the indentation of the key owning a block scalar, or -1
+	blockIndent := -1
+	for i, line := range lines {
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if blockIndent >= 0 {
+			if strings.TrimSpace(line) == "" || indent > blockIndent {
+				lines[i] = "[green]" + tview.Escape(line) + "[-]"
+				continue
+			}
+			blockIndent = -1
+		}
+		if isBlockScalarStart(line) {
+			blockIndent = indent
+		}
+		lines[i] = highlightYAMLLine(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isBlockScalarStart(line string) bool {
+	m := yamlKeyPattern.FindStringSubmatch(line)
+	if m == nil {
+		return false
+	}
+	value := strings.TrimSpace(m[5])
+	return value != "" && (value[0] == '|' || value[0] == '>')
+}
+
+func highlightYAMLLine(line string) string {
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case trimmed == "":
+		return line
+	case strings.HasPrefix(trimmed, "#"):
+		return "[gray]" + tview.Escape(line) + "[-]"
+	case trimmed == "---":
+		return "[gray]" + line + "[-]"
+	}
+
+	if m := yamlKeyPattern.FindStringSubmatch(line); m != nil {
+		indent, dash, key, sep, value := m[1], m[2], m[3], m[4], m[5]
+		return indent + dash + "[#00bebe]" + tview.Escape(key) + "[-]:" + sep + highlightYAMLValue(value)
+	}
+	if m := yamlListPattern.FindStringSubmatch(line); m != nil {
+		return m[1] + "- " + highlightYAMLValue(m[2])
+	}
+	return highlightYAMLValue(line)
+}
+
+func highlightYAMLValue(value string) string {
+	trimmed := strings.TrimSpace(value)
+	switch {
+	case trimmed == "":
+		return value
+	case trimmed[0] == '|' || trimmed[0] == '>':
+		return "[gray]" + tview.Escape(value) + "[-]"
+	case yamlScalarPattern.MatchString(trimmed):
+		return "[orange]" + tview.Escape(value) + "[-]"
+	default:
+		return "[green]" + tview.Escape(value) + "[-]"
+	}
+}
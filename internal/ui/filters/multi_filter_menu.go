@@ -13,6 +13,9 @@ type FilterCategory struct {
 	Type      FilterType
 	Options   []string
 	Shortcuts map[string]rune
+	// MultiSelect lets several options be selected at once, matching any of
+	// them. Otherwise selecting an option replaces the previous one.
+	MultiSelect bool
 }
 
 type MultiFilterOptions struct {
@@ -28,9 +31,19 @@ func GetActiveFiltersText(filters []FilterState) string {
 		return "None"
 	}
 
-	var parts []string
+	// Values of the same type are alternatives, shown together
+	var types []FilterType
+	values := make(map[FilterType][]string)
 	for _, filter := range filters {
-		parts = append(parts, fmt.Sprintf("%s=%s", filter.Type, filter.Value))
+		if _, ok := values[filter.Type]; !ok {
+			types = append(types, filter.Type)
+		}
+		values[filter.Type] = append(values[filter.Type], filter.Value)
+	}
+
+	parts := make([]string, 0, len(types))
+	for _, filterType := range types {
+		parts = append(parts, fmt.Sprintf("%s=%s", filterType, strings.Join(values[filterType], "|")))
 	}
 	return strings.Join(parts, ", ")
 }
@@ -87,6 +100,7 @@ func ShowMultiFilterMenu(
 	rightPanel.SetBackgroundColor(theme.Background)
 
 	categoryViews := make(map[FilterType]*tview.List)
+	var refreshers []func()
 
 	for _, category := range options.Categories {
 		localCategory := category
@@ -102,55 +116,44 @@ func ShowMultiFilterMenu(
 			SetTitleColor(theme.HeaderText)
 		optionsList.SetBackgroundColor(theme.Background)
 
-		optionsList.AddItem("All (clear filter)", "", 'a', func() {
-			currentFilters = UpdateFilter(currentFilters, localCategory.Type, "")
+		// refreshOptions checks the selected options, item 0 being "All"
+		refreshOptions := func() {
+			for i, opt := range localCategory.Options {
+				text := opt
+				if localCategory.Type == HealthFilter {
+					text = StyleText(opt, GetHealthStatusColor(opt))
+				} else if localCategory.Type == SyncFilter {
+					text = StyleText(opt, GetSyncStatusColor(opt))
+				}
+				if HasFilterValue(currentFilters, localCategory.Type, opt) {
+					text = "✓ " + text
+				}
+				optionsList.SetItemText(i+1, text, "")
+			}
 			updateFiltersText()
-		})
-
-		currentValue, _ := FindFilterByType(currentFilters, localCategory.Type)
+		}
+		selectOption := func(option string) {
+			if localCategory.MultiSelect {
+				currentFilters = ToggleFilterValue(currentFilters, localCategory.Type, option)
+			} else {
+				currentFilters = UpdateFilter(currentFilters, localCategory.Type, option)
+			}
+			refreshOptions()
+		}
+		clearOptions := func() {
+			currentFilters = UpdateFilter(currentFilters, localCategory.Type, "")
+			refreshOptions()
+		}
 
+		optionsList.AddItem("All (clear filter)", "", 'a', clearOptions)
 		for _, option := range localCategory.Options {
 			localOption := option
-
-			var shortcut rune = 0
-			if shortcutRune, ok := localCategory.Shortcuts[option]; ok {
-				shortcut = shortcutRune
-			}
-
-			displayText := option
-
-			if localCategory.Type == HealthFilter {
-				displayText = StyleText(option, GetHealthStatusColor(option))
-			} else if localCategory.Type == SyncFilter {
-				displayText = StyleText(option, GetSyncStatusColor(option))
-			}
-
-			if currentValue == option {
-				displayText = "✓ " + displayText
-			}
-
-			optionsList.AddItem(displayText, "", shortcut, func() {
-				currentFilters = UpdateFilter(currentFilters, localCategory.Type, localOption)
-
-				for i, opt := range localCategory.Options {
-					text := opt
-
-					if localCategory.Type == HealthFilter {
-						text = StyleText(opt, GetHealthStatusColor(opt))
-					} else if localCategory.Type == SyncFilter {
-						text = StyleText(opt, GetSyncStatusColor(opt))
-					}
-
-					if opt == localOption {
-						text = "✓ " + text
-					}
-
-					optionsList.SetItemText(i+1, text, "")
-				}
-
-				updateFiltersText()
+			optionsList.AddItem(option, "", localCategory.Shortcuts[option], func() {
+				selectOption(localOption)
 			})
 		}
+		refreshOptions()
+		refreshers = append(refreshers, refreshOptions)
 
 		optionsList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 			if event.Key() == tcell.KeyEnter {
@@ -181,50 +184,15 @@ func ShowMultiFilterMenu(
 
 			if event.Key() == tcell.KeyRune {
 				if event.Rune() == 'a' || event.Rune() == 'A' {
-					currentFilters = UpdateFilter(currentFilters, localCategory.Type, "")
-
-					for i, opt := range localCategory.Options {
-						text := opt
-
-						if localCategory.Type == HealthFilter {
-							text = StyleText(opt, GetHealthStatusColor(opt))
-						} else if localCategory.Type == SyncFilter {
-							text = StyleText(opt, GetSyncStatusColor(opt))
-						}
-
-						optionsList.SetItemText(i+1, text, "")
-					}
-
-					updateFiltersText()
+					clearOptions()
 					return nil
 				}
 
 				for i, opt := range localCategory.Options {
-					if shortcutRune, ok := localCategory.Shortcuts[opt]; ok {
-						if event.Rune() == shortcutRune {
-							optionsList.SetCurrentItem(i + 1) // +1 для учета "All"
-
-							currentFilters = UpdateFilter(currentFilters, localCategory.Type, opt)
-
-							for j, option := range localCategory.Options {
-								text := option
-
-								if localCategory.Type == HealthFilter {
-									text = StyleText(option, GetHealthStatusColor(option))
-								} else if localCategory.Type == SyncFilter {
-									text = StyleText(option, GetSyncStatusColor(option))
-								}
-
-								if option == opt {
-									text = "✓ " + text
-								}
-
-								optionsList.SetItemText(j+1, text, "")
-							}
-
-							updateFiltersText()
-							return nil
-						}
+					if shortcutRune, ok := localCategory.Shortcuts[opt]; ok && event.Rune() == shortcutRune {
+						optionsList.SetCurrentItem(i + 1) // +1 для учета "All"
+						selectOption(opt)
+						return nil
 					}
 				}
 			}
@@ -260,10 +228,14 @@ func ShowMultiFilterMenu(
 		modal.Close()
 	})
 
-	categoryList.AddItem("Clear All Filters", "", 0, func() {
+	clearAll := func() {
 		currentFilters = []FilterState{}
+		for _, refresh := range refreshers {
+			refresh()
+		}
 		updateFiltersText()
-	})
+	}
+	categoryList.AddItem("Clear All Filters", "", 0, clearAll)
 
 	categoryList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		if event.Key() == tcell.KeyEnter {
@@ -278,8 +250,7 @@ func ShowMultiFilterMenu(
 				return nil
 			}
 			if categoryList.GetCurrentItem() == len(options.Categories)+1 {
-				currentFilters = []FilterState{}
-				updateFiltersText()
+				clearAll()
 				return nil
 			}
 
@@ -342,6 +313,8 @@ func CreateFooterWithNavigation(theme ThemeColors) *tview.TextView {
 			StyleText(" Navigate  ", tcell.ColorGray) +
 			StyleText("←/→", theme.ShortcutKey) +
 			StyleText(" Change panel  ", tcell.ColorGray) +
+			StyleText("Space", theme.ShortcutKey) +
+			StyleText(" Select  ", tcell.ColorGray) +
 			StyleText("b", theme.ShortcutKey) +
 			StyleText(" Back  ", tcell.ColorGray) +
 			StyleText("Enter", theme.ShortcutKey) +
@@ -9,6 +9,7 @@ import (
 
 const (
 	ResourceKindFilter FilterType = "resourceKind"
+	NamespaceFilter    FilterType = "namespace"
 )
 
 type ResourceFilterManager struct {
@@ -17,6 +18,7 @@ type ResourceFilterManager struct {
 	kindTypes      map[string]bool
 	namespaces     map[string]bool
 	kinds          []string
+	namespaceNames []string
 	healthStatuses []string
 	syncStatuses   []string
 
@@ -63,33 +65,57 @@ func (f *ResourceFilterManager) ExtractKindsFromResources(kinds map[string]bool)
 	sort.Strings(f.kinds)
 }
 
+func (f *ResourceFilterManager) ExtractNamespacesFromResources(namespaces map[string]bool) {
+	f.namespaces = namespaces
+
+	f.namespaceNames = make([]string, 0, len(f.namespaces))
+	for namespace := range f.namespaces {
+		f.namespaceNames = append(f.namespaceNames, namespace)
+	}
+	sort.Strings(f.namespaceNames)
+}
+
+// ShowFilterMenu lets several values be selected per category. Resources
+// match when they match any value of every filtered category.
 func (f *ResourceFilterManager) ShowFilterMenu() {
 	categories := []FilterCategory{}
 
 	if len(f.kinds) > 0 {
 		categories = append(categories, FilterCategory{
-			Title:     "Kind",
-			Type:      ResourceKindFilter,
-			Options:   f.kinds,
-			Shortcuts: f.getKindShortcuts(),
+			Title:       "Kind",
+			Type:        ResourceKindFilter,
+			Options:     f.kinds,
+			Shortcuts:   f.getKindShortcuts(),
+			MultiSelect: true,
+		})
+	}
+
+	if len(f.namespaceNames) > 0 {
+		categories = append(categories, FilterCategory{
+			Title:       "Namespace",
+			Type:        NamespaceFilter,
+			Options:     f.namespaceNames,
+			MultiSelect: true,
 		})
 	}
 
 	if len(f.healthStatuses) > 0 {
 		categories = append(categories, FilterCategory{
-			Title:     "Health Status",
-			Type:      HealthFilter,
-			Options:   f.healthStatuses,
-			Shortcuts: StandardHealthShortcuts(),
+			Title:       "Health Status",
+			Type:        HealthFilter,
+			Options:     f.healthStatuses,
+			Shortcuts:   StandardHealthShortcuts(),
+			MultiSelect: true,
 		})
 	}
 
 	if len(f.syncStatuses) > 0 {
 		categories = append(categories, FilterCategory{
-			Title:     "Sync Status",
-			Type:      SyncFilter,
-			Options:   f.syncStatuses,
-			Shortcuts: StandardSyncShortcuts(),
+			Title:       "Sync Status",
+			Type:        SyncFilter,
+			Options:     f.syncStatuses,
+			Shortcuts:   StandardSyncShortcuts(),
+			MultiSelect: true,
 		})
 	}
 
@@ -144,18 +170,19 @@ func (f *ResourceFilterManager) GetFilter(filterType FilterType) string {
 	return ""
 }
 
+func (f *ResourceFilterManager) GetFilterValues(filterType FilterType) []string {
+	return FilterValues(f.Filters, filterType)
+}
+
 func (f *ResourceFilterManager) ClearFilters() {
 	f.Filters = []FilterState{}
 	f.applyFilters()
 }
 
+// ToggleFilter adds value to the selected values of filterType, or removes it.
 func (f *ResourceFilterManager) ToggleFilter(filterType FilterType, value string) {
-	currentValue, found := FindFilterByType(f.Filters, filterType)
-	if found && currentValue == value {
-		f.SetFilter(filterType, "")
-	} else {
-		f.SetFilter(filterType, value)
-	}
+	f.Filters = ToggleFilterValue(f.Filters, filterType, value)
+	f.applyFilters()
 }
 
 func (f *ResourceFilterManager) applyFilters() {
@@ -13,6 +13,8 @@ const (
 	SyncFilter    FilterType = "sync"
 )
 
+// FilterState is an active filter. A type may appear several times, its
+// values are then alternatives: they match when any of them does.
 type FilterState struct {
 	Type  FilterType
 	Value string
@@ -94,23 +96,58 @@ func FindFilterByType(filters []FilterState, filterType FilterType) (string, boo
 	return "", false
 }
 
+// UpdateFilter replaces the values of filterType with value, or removes the
+// filter when value is empty.
 func UpdateFilter(filters []FilterState, filterType FilterType, value string) []FilterState {
-	if value == "" {
-		result := make([]FilterState, 0, len(filters))
-		for _, filter := range filters {
-			if filter.Type != filterType {
-				result = append(result, filter)
-			}
+	result := make([]FilterState, 0, len(filters)+1)
+	replaced := false
+	for _, filter := range filters {
+		if filter.Type != filterType {
+			result = append(result, filter)
+			continue
+		}
+		if value != "" && !replaced {
+			result = append(result, FilterState{Type: filterType, Value: value})
+			replaced = true
 		}
-		return result
 	}
+	if value != "" && !replaced {
+		result = append(result, FilterState{Type: filterType, Value: value})
+	}
+	return result
+}
 
-	for i, filter := range filters {
+// FilterValues returns the selected values of filterType.
+func FilterValues(filters []FilterState, filterType FilterType) []string {
+	var values []string
+	for _, filter := range filters {
 		if filter.Type == filterType {
-			filters[i].Value = value
-			return filters
+			values = append(values, filter.Value)
 		}
 	}
+	return values
+}
 
-	return append(filters, FilterState{Type: filterType, Value: value})
+func HasFilterValue(filters []FilterState, filterType FilterType, value string) bool {
+	for _, filter := range filters {
+		if filter.Type == filterType && filter.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ToggleFilterValue selects value for filterType next to the values already
+// selected, or deselects it.
+func ToggleFilterValue(filters []FilterState, filterType FilterType, value string) []FilterState {
+	result := make([]FilterState, 0, len(filters)+1)
+	for _, filter := range filters {
+		if filter.Type != filterType || filter.Value != value {
+			result = append(result, filter)
+		}
+	}
+	if len(result) == len(filters) {
+		result = append(result, FilterState{Type: filterType, Value: value})
+	}
+	return result
 }
@@ -57,6 +57,27 @@ func (r *Router) AddScreen(s Screen) error {
 	return nil
 }
 
+// ReplaceScreen registers s, dropping any screen previously added under the same name.
+func (r *Router) ReplaceScreen(s Screen) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if s == nil {
+		return errors.New("screen cannot be nil")
+	}
+
+	name := s.Name()
+	if name == "" {
+		return errors.New("screen name cannot be empty")
+	}
+
+	if r.current != nil && r.current.Name() == name {
+		r.current = s
+	}
+	r.screens[name] = s
+	return nil
+}
+
 func (r *Router) SwitchTo(name string) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
@@ -135,6 +156,25 @@ func (r *Router) CloseModal() {
 	})
 }
 
+// ShowOverlay displays a full-screen dialog owned by the current screen. Like
+// ShowModal it suspends the global key bindings, so dialogs with text input
+// can receive 'q' and Esc.
+func (r *Router) ShowOverlay(overlay tview.Primitive) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.isModal = true
+	r.app.SetRoot(overlay, true)
+	r.app.SetFocus(overlay)
+}
+
+// CloseOverlay restores root without re-initializing the current screen.
+func (r *Router) CloseOverlay(root tview.Primitive) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.isModal = false
+	r.app.SetRoot(root, true)
+}
+
 func (r *Router) IsModalActive() bool {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
@@ -0,0 +1,230 @@
+package applicationDetails
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd"
+	"github.com/Jack200062/ArguTUI/internal/ui"
+	"github.com/Jack200062/ArguTUI/internal/ui/common"
+	"github.com/Jack200062/ArguTUI/internal/ui/components"
+	"github.com/Jack200062/ArguTUI/internal/ui/screens/applicationHistory"
+	"github.com/Jack200062/ArguTUI/internal/ui/screens/applicationParameters"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const timeLayout = "2006-01-02 15:04:05"
+
+// ScreenAppDetails shows source, destination, sync policy and status of an application.
+type ScreenAppDetails struct {
+	app          *tview.Application
+	instanceInfo *common.InstanceInfo
+	client       argocd.Backend
+	router       *ui.Router
+
+	appName string
+
+	view *tview.TextView
+}
+
+func New(
+	app *tview.Application,
+	r *ui.Router,
+	instanceInfo *common.InstanceInfo,
+	client argocd.Backend,
+	appName string,
+) *ScreenAppDetails {
+	return &ScreenAppDetails{
+		app:          app,
+		instanceInfo: instanceInfo,
+		client:       client,
+		router:       r,
+		appName:      appName,
+	}
+}
+
+func (s *ScreenAppDetails) Init() tview.Primitive {
+	textColor := tcell.NewHexColor(0x00bebe)
+	backgroundColor := tcell.NewHexColor(0x000000)
+	borderColor := tcell.NewHexColor(0x63a0bf)
+	shortcutKeyColor := tcell.NewHexColor(0x017be9)
+
+	instanceView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(s.instanceInfo.FormattedString(tcell.ColorYellow)).
+		SetTextAlign(tview.AlignLeft)
+	instanceView.SetBackgroundColor(backgroundColor)
+
+	shortcutBar := components.NewShortcutBar(backgroundColor, shortcutKeyColor)
+	shortcutBar.AddGroup("Details", map[string]string{
+		"h": "History/Rollback",
+		"p": "Parameters",
+		"r": "Reload",
+	})
+	shortcutBar.AddGroup("Navigation", map[string]string{
+		"b": "Back",
+		"q": "Quit",
+	})
+
+	topBar := tview.NewFlex().
+		SetDirection(tview.FlexColumn).
+		AddItem(instanceView, 0, 1, false).
+		AddItem(shortcutBar.Init(), 0, 2, false)
+	topBar.SetBackgroundColor(backgroundColor)
+
+	s.view = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true).
+		SetScrollable(true)
+	s.view.SetBackgroundColor(backgroundColor)
+	s.view.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Details of %s ", s.appName)).
+		SetBorderColor(borderColor).
+		SetTitleColor(textColor).
+		SetTitleAlign(tview.AlignCenter)
+	s.view.SetInputCapture(s.onKey)
+
+	grid := tview.NewGrid().
+		SetRows(3, 0).
+		SetColumns(0).
+		SetBorders(true)
+	grid.AddItem(topBar, 0, 0, 1, 1, 0, 0, false).
+		AddItem(s.view, 1, 0, 1, 1, 0, 0, true)
+
+	s.load()
+	return grid
+}
+
+func (s *ScreenAppDetails) onKey(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Rune() {
+	case 'b':
+		s.router.Back()
+		return nil
+	case 'r':
+		s.load()
+		return nil
+	case 'h':
+		historyScreen := applicationHistory.New(s.app, s.router, s.instanceInfo, s.client, s.appName)
+		s.router.ReplaceScreen(historyScreen)
+		s.router.SwitchTo(historyScreen.Name())
+		return nil
+	case 'p':
+		parametersScreen := applicationParameters.New(s.app, s.router, s.instanceInfo, s.client, s.appName)
+		s.router.ReplaceScreen(parametersScreen)
+		s.router.SwitchTo(parametersScreen.Name())
+		return nil
+	}
+	return event
+}
+
+func (s *ScreenAppDetails) load() {
+	app, err := s.client.GetApp(s.appName)
+	if err != nil {
+		s.view.SetText(fmt.Sprintf("[red]Error loading application: %s[-]", tview.Escape(err.Error())))
+		return
+	}
+	s.view.SetText(renderDetails(app))
+}
+
+func renderDetails(app argocd.Application) string {
+	var b strings.Builder
+
+	section(&b, "SOURCE")
+	field(&b, "Repository", app.RepoURL)
+	if app.Chart != "" {
+		field(&b, "Chart", app.Chart)
+	} else {
+		field(&b, "Path", app.Path)
+	}
+	field(&b, "Target revision", app.TargetRevision)
+	field(&b, "Synced revision", app.SyncRevision)
+
+	section(&b, "DESTINATION")
+	if app.DestName != "" {
+		field(&b, "Cluster", app.DestName)
+	} else {
+		field(&b, "Cluster", app.DestServer)
+	}
+	field(&b, "Namespace", app.DestNamespace)
+	field(&b, "Project", app.Project)
+
+	section(&b, "SYNC POLICY")
+	if app.SyncPolicy.Automated {
+		field(&b, "Automated", fmt.Sprintf("enabled (prune: %t, self heal: %t, allow empty: %t)",
+			app.SyncPolicy.Prune, app.SyncPolicy.SelfHeal, app.SyncPolicy.AllowEmpty))
+	} else {
+		field(&b, "Automated", "disabled")
+	}
+	field(&b, "Sync options", strings.Join(app.SyncPolicy.SyncOptions, ", "))
+
+	section(&b, "STATUS")
+	fmt.Fprintf(&b, "  [gray]%-16s[-] [%s]%s[-]\n", "Health",
+		common.ColorForHealthStatus(app.HealthStatus), tview.Escape(app.HealthStatus))
+	field(&b, "Health message", app.HealthMessage)
+	syncColor := "green"
+	if !strings.EqualFold(app.SyncStatus, "Synced") {
+		syncColor = "orange"
+	}
+	fmt.Fprintf(&b, "  [gray]%-16s[-] [%s]%s[-]\n", "Sync", syncColor, tview.Escape(app.SyncStatus))
+	field(&b, "Last activity", app.LastActivity)
+
+	section(&b, "OPERATION")
+	if op := app.Operation; op != nil {
+		field(&b, "Phase", op.Phase)
+		field(&b, "Message", op.Message)
+		field(&b, "Revision", op.Revision)
+		field(&b, "Started", formatTime(op.StartedAt))
+		field(&b, "Finished", formatTime(op.FinishedAt))
+	} else {
+		b.WriteString("  [gray]No operation has run yet[-]\n")
+	}
+
+	section(&b, "CONDITIONS")
+	if len(app.Conditions) == 0 {
+		b.WriteString("  [gray]None[-]\n")
+	}
+	for _, cond := range app.Conditions {
+		color := "orange"
+		if strings.HasSuffix(cond.Type, "Error") {
+			color = "red"
+		}
+		fmt.Fprintf(&b, "  [%s]%s[-] %s\n", color, tview.Escape(cond.Type), tview.Escape(cond.Message))
+	}
+
+	section(&b, "IMAGES")
+	if len(app.Images) == 0 {
+		b.WriteString("  [gray]None[-]\n")
+	}
+	for _, image := range app.Images {
+		fmt.Fprintf(&b, "  %s\n", tview.Escape(image))
+	}
+
+	return b.String()
+}
+
+func section(b *strings.Builder, title string) {
+	if b.Len() > 0 {
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(b, "[yellow::b]%s[-:-:-]\n", title)
+}
+
+func field(b *strings.Builder, name, value string) {
+	if value == "" {
+		value = "-"
+	}
+	fmt.Fprintf(b, "  [gray]%-16s[-] %s\n", name, tview.Escape(value))
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format(timeLayout)
+}
+
+func (s *ScreenAppDetails) Name() string {
+	return "ApplicationDetails"
+}
@@ -0,0 +1,309 @@
+package applicationHistory
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd"
+	"github.com/Jack200062/ArguTUI/internal/ui"
+	"github.com/Jack200062/ArguTUI/internal/ui/common"
+	"github.com/Jack200062/ArguTUI/internal/ui/components"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const timeLayout = "2006-01-02 15:04:05"
+
+// ScreenAppHistory lists the deployments of an application, newest first,
+// and rolls back to a selected one.
+type ScreenAppHistory struct {
+	app          *tview.Application
+	instanceInfo *common.InstanceInfo
+	client       argocd.Backend
+	router       *ui.Router
+
+	appName string
+
+	pages      *tview.Pages
+	table      *tview.Table
+	statusView *tview.TextView
+
+	history []argocd.HistoryEntry
+	// metadata caches the revision metadata fetched successfully, so that a
+	// failed fetch is retried on the next refresh
+	metadata map[string]*argocd.RevisionMetadata
+	// loading holds the revisions being fetched
+	loading map[string]bool
+}
+
+func New(
+	app *tview.Application,
+	r *ui.Router,
+	instanceInfo *common.InstanceInfo,
+	client argocd.Backend,
+	appName string,
+) *ScreenAppHistory {
+	return &ScreenAppHistory{
+		app:          app,
+		instanceInfo: instanceInfo,
+		client:       client,
+		router:       r,
+		appName:      appName,
+		metadata:     make(map[string]*argocd.RevisionMetadata),
+		loading:      make(map[string]bool),
+	}
+}
+
+func (s *ScreenAppHistory) Init() tview.Primitive {
+	textColor := tcell.NewHexColor(0x00bebe)
+	backgroundColor := tcell.NewHexColor(0x000000)
+	borderColor := tcell.NewHexColor(0x63a0bf)
+	shortcutKeyColor := tcell.NewHexColor(0x017be9)
+	selectedBgColor := tcell.NewHexColor(0x373737)
+
+	instanceView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(s.instanceInfo.FormattedString(tcell.ColorYellow)).
+		SetTextAlign(tview.AlignLeft)
+	instanceView.SetBackgroundColor(backgroundColor)
+
+	shortcutBar := components.NewShortcutBar(backgroundColor, shortcutKeyColor)
+	shortcutBar.AddGroup("History", map[string]string{
+		"R": "Rollback",
+		"r": "Reload",
+	})
+	shortcutBar.AddGroup("Navigation", map[string]string{
+		"b": "Back",
+		"q": "Quit",
+	})
+
+	topBar := tview.NewFlex().
+		SetDirection(tview.FlexColumn).
+		AddItem(instanceView, 0, 1, false).
+		AddItem(shortcutBar.Init(), 0, 2, false)
+	topBar.SetBackgroundColor(backgroundColor)
+
+	s.table = tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSelectedStyle(tcell.StyleDefault.
+			Background(selectedBgColor).
+			Foreground(textColor))
+	s.table.SetBorder(true).
+		SetTitle(fmt.Sprintf(" History of %s ", s.appName)).
+		SetTitleAlign(tview.AlignCenter).
+		SetTitleColor(textColor).
+		SetBorderColor(borderColor).
+		SetBackgroundColor(backgroundColor)
+	s.table.SetInputCapture(s.onKey)
+
+	s.statusView = tview.NewTextView().SetDynamicColors(true)
+	s.statusView.SetBackgroundColor(backgroundColor)
+
+	grid := tview.NewGrid().
+		SetRows(3, 0, 1).
+		SetColumns(0).
+		SetBorders(true)
+	grid.AddItem(topBar, 0, 0, 1, 1, 0, 0, false).
+		AddItem(s.table, 1, 0, 1, 1, 0, 0, true).
+		AddItem(s.statusView, 2, 0, 1, 1, 0, 0, false)
+
+	s.pages = tview.NewPages().
+		AddPage("main", grid, true, true)
+
+	s.load()
+	return s.pages
+}
+
+func (s *ScreenAppHistory) onKey(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Rune() {
+	case 'b':
+		s.router.Back()
+		return nil
+	case 'r':
+		s.load()
+		return nil
+	case 'R':
+		if entry, ok := s.selectedEntry(); ok {
+			s.showRollbackDialog(entry)
+		}
+		return nil
+	}
+	return event
+}
+
+func (s *ScreenAppHistory) load() {
+	history, err := s.client.GetHistory(s.appName)
+	if err != nil {
+		s.statusView.SetText(fmt.Sprintf("[red]Error loading history: %s[-]", tview.Escape(err.Error())))
+		return
+	}
+	// Newest deployment first
+	s.history = make([]argocd.HistoryEntry, len(history))
+	for i, entry := range history {
+		s.history[len(history)-1-i] = entry
+	}
+	s.fillTable()
+	s.loadMetadata()
+}
+
+// loadMetadata fetches author and message of every revision not cached yet
+// in the background and fills them in as they arrive.
+func (s *ScreenAppHistory) loadMetadata() {
+	var missing []string
+	for _, entry := range s.history {
+		if _, ok := s.metadata[entry.Revision]; !ok && !s.loading[entry.Revision] && entry.Revision != "" {
+			missing = append(missing, entry.Revision)
+			s.loading[entry.Revision] = true
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	go func() {
+		fetched := make(map[string]*argocd.RevisionMetadata, len(missing))
+		for _, revision := range missing {
+			if meta, err := s.client.GetRevisionMetadata(s.appName, revision); err == nil {
+				fetched[revision] = meta
+			}
+		}
+		s.app.QueueUpdateDraw(func() {
+			for _, revision := range missing {
+				delete(s.loading, revision)
+			}
+			for revision, meta := range fetched {
+				s.metadata[revision] = meta
+			}
+			s.fillTable()
+		})
+	}()
+}
+
+func (s *ScreenAppHistory) fillTable() {
+	row, _ := s.table.GetSelection()
+	s.table.Clear()
+
+	headers := []string{"ID", "Revision", "Deployed At", "Initiated By", "Author", "Message", "Source"}
+	for col, h := range headers {
+		s.table.SetCell(0, col, tview.NewTableCell(fmt.Sprintf("[::b]%s", h)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false))
+	}
+
+	if len(s.history) == 0 {
+		s.table.SetCell(1, 0, tview.NewTableCell("No deployments yet").
+			SetTextColor(tcell.ColorGray).
+			SetSelectable(false))
+		s.statusView.SetText("")
+		return
+	}
+
+	for i, entry := range s.history {
+		var author, message string
+		if meta := s.metadata[entry.Revision]; meta != nil {
+			author = meta.Author
+			message, _, _ = strings.Cut(meta.Message, "\n")
+		}
+		cells := []string{
+			fmt.Sprintf("%d", entry.ID),
+			shortRevision(entry.Revision),
+			entry.DeployedAt.Local().Format(timeLayout),
+			entry.InitiatedBy,
+			author,
+			message,
+			entry.Source,
+		}
+		color := tcell.ColorWhite
+		if i == 0 {
+			// The most recent deployment is what is running now
+			color = tcell.ColorGreen
+		}
+		for col, text := range cells {
+			cell := tview.NewTableCell(tview.Escape(text)).SetTextColor(color)
+			if col == 5 {
+				cell.SetExpansion(1).SetMaxWidth(60)
+			}
+			s.table.SetCell(i+1, col, cell)
+		}
+	}
+
+	if row < 1 {
+		row = 1
+	}
+	s.table.Select(min(row, len(s.history)), 0)
+	s.statusView.SetText(fmt.Sprintf("[gray]Deployments: [#63a0bf]%d", len(s.history)))
+}
+
+func (s *ScreenAppHistory) selectedEntry() (argocd.HistoryEntry, bool) {
+	row, _ := s.table.GetSelection()
+	if row < 1 || row-1 >= len(s.history) {
+		return argocd.HistoryEntry{}, false
+	}
+	return s.history[row-1], true
+}
+
+func (s *ScreenAppHistory) showRollbackDialog(entry argocd.HistoryEntry) {
+	textColor := tcell.NewHexColor(0x00bebe)
+	prune := false
+
+	form := tview.NewForm().
+		SetFieldBackgroundColor(tcell.NewHexColor(0x1a1a1a)).
+		SetFieldTextColor(tcell.ColorWhite).
+		SetLabelColor(textColor).
+		SetButtonBackgroundColor(tcell.ColorDarkRed).
+		SetButtonTextColor(tcell.ColorWhite)
+	form.SetBackgroundColor(tcell.NewHexColor(0x000000)).
+		SetBorderColor(tcell.ColorDarkRed).
+		SetBorder(true).
+		SetTitle(fmt.Sprintf(" Roll back %s to #%d (%s)? ", s.appName, entry.ID, shortRevision(entry.Revision))).
+		SetTitleAlign(tview.AlignCenter).
+		SetTitleColor(textColor)
+
+	form.AddCheckbox("Prune", false, func(checked bool) {
+		prune = checked
+	})
+	form.AddButton("Rollback", func() {
+		s.router.CloseOverlay(s.pages)
+		s.rollback(entry, prune)
+	})
+	form.AddButton("Cancel", func() {
+		s.router.CloseOverlay(s.pages)
+	})
+	form.SetButtonsAlign(tview.AlignCenter)
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			s.router.CloseOverlay(s.pages)
+			return nil
+		}
+		return event
+	})
+
+	s.router.ShowOverlay(components.Centered(form, 60, 7))
+}
+
+func (s *ScreenAppHistory) rollback(entry argocd.HistoryEntry, prune bool) {
+	if err := s.client.Rollback(s.appName, entry.ID, prune); err != nil {
+		modal := components.ErrorModal(
+			fmt.Sprintf("Error rolling back %s:", s.appName),
+			err.Error(),
+			func() { s.app.SetRoot(s.pages, true) },
+		)
+		s.app.SetRoot(modal, true)
+		return
+	}
+	s.statusView.SetText(fmt.Sprintf("[green]Rollback of %s to #%d started[-]", s.appName, entry.ID))
+}
+
+// shortRevision abbreviates git SHAs and keeps chart versions as they are.
+func shortRevision(revision string) string {
+	if len(revision) == 40 {
+		return revision[:7]
+	}
+	return revision
+}
+
+func (s *ScreenAppHistory) Name() string {
+	return "ApplicationHistory"
+}
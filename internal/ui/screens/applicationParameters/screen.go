@@ -0,0 +1,451 @@
+package applicationParameters
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd"
+	"github.com/Jack200062/ArguTUI/internal/ui"
+	"github.com/Jack200062/ArguTUI/internal/ui/common"
+	"github.com/Jack200062/ArguTUI/internal/ui/components"
+	"github.com/Jack200062/ArguTUI/pkg/diff"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+type rowKind int
+
+const (
+	rowValueFiles rowKind = iota
+	rowHelm
+	rowImage
+	rowNamePrefix
+	rowNameSuffix
+)
+
+// row maps a table row to the parameter it shows. index is only used for
+// Helm parameters and images.
+type row struct {
+	kind  rowKind
+	index int
+}
+
+// ScreenAppParameters shows the Helm or Kustomize parameters of an
+// application and edits their overrides. Changes are kept locally until
+// they are saved through a spec update.
+type ScreenAppParameters struct {
+	app          *tview.Application
+	instanceInfo *common.InstanceInfo
+	client       argocd.Backend
+	router       *ui.Router
+
+	appName string
+
+	pages      *tview.Pages
+	table      *tview.Table
+	statusView *tview.TextView
+
+	params argocd.AppParameters
+	rows   []row
+	loaded bool
+	dirty  bool
+}
+
+func New(
+	app *tview.Application,
+	r *ui.Router,
+	instanceInfo *common.InstanceInfo,
+	client argocd.Backend,
+	appName string,
+) *ScreenAppParameters {
+	return &ScreenAppParameters{
+		app:          app,
+		instanceInfo: instanceInfo,
+		client:       client,
+		router:       r,
+		appName:      appName,
+	}
+}
+
+func (s *ScreenAppParameters) Init() tview.Primitive {
+	textColor := tcell.NewHexColor(0x00bebe)
+	backgroundColor := tcell.NewHexColor(0x000000)
+	borderColor := tcell.NewHexColor(0x63a0bf)
+	shortcutKeyColor := tcell.NewHexColor(0x017be9)
+	selectedBgColor := tcell.NewHexColor(0x373737)
+
+	instanceView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(s.instanceInfo.FormattedString(tcell.ColorYellow)).
+		SetTextAlign(tview.AlignLeft)
+	instanceView.SetBackgroundColor(backgroundColor)
+
+	shortcutBar := components.NewShortcutBar(backgroundColor, shortcutKeyColor)
+	shortcutBar.AddGroup("Parameters", map[string]string{
+		"Enter": "Edit",
+		"n":     "New Helm parameter",
+		"x":     "Reset to default",
+		"s":     "Save",
+		"r":     "Reload",
+	})
+	shortcutBar.AddGroup("Navigation", map[string]string{
+		"b": "Back",
+		"q": "Quit",
+	})
+
+	topBar := tview.NewFlex().
+		SetDirection(tview.FlexColumn).
+		AddItem(instanceView, 0, 1, false).
+		AddItem(shortcutBar.Init(), 0, 2, false)
+	topBar.SetBackgroundColor(backgroundColor)
+
+	s.table = tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSelectedStyle(tcell.StyleDefault.
+			Background(selectedBgColor).
+			Foreground(textColor))
+	s.table.SetBorder(true).
+		SetTitleAlign(tview.AlignCenter).
+		SetTitleColor(textColor).
+		SetBorderColor(borderColor).
+		SetBackgroundColor(backgroundColor)
+	s.table.SetInputCapture(s.onKey)
+
+	s.statusView = tview.NewTextView().SetDynamicColors(true)
+	s.statusView.SetBackgroundColor(backgroundColor)
+
+	grid := tview.NewGrid().
+		SetRows(3, 0, 1).
+		SetColumns(0).
+		SetBorders(true)
+	grid.AddItem(topBar, 0, 0, 1, 1, 0, 0, false).
+		AddItem(s.table, 1, 0, 1, 1, 0, 0, true).
+		AddItem(s.statusView, 2, 0, 1, 1, 0, 0, false)
+
+	s.pages = tview.NewPages().
+		AddPage("main", grid, true, true)
+
+	if s.loaded {
+		s.fillTable()
+	} else {
+		s.load()
+	}
+	return s.pages
+}
+
+func (s *ScreenAppParameters) load() {
+	params, err := s.client.GetAppParameters(s.appName)
+	if err != nil {
+		s.table.Clear()
+		s.table.SetTitle(fmt.Sprintf(" Parameters of %s ", s.appName))
+		s.statusView.SetText(fmt.Sprintf("[red]Error loading parameters: %s[-]", tview.Escape(err.Error())))
+		return
+	}
+	s.params = params
+	s.loaded = true
+	s.dirty = false
+	s.fillTable()
+}
+
+func (s *ScreenAppParameters) fillTable() {
+	selected, _ := s.table.GetSelection()
+	s.table.Clear()
+	s.table.SetTitle(fmt.Sprintf(" %s parameters of %s ", s.params.SourceType, s.appName))
+
+	headers := []string{"Name", "Value", "Default"}
+	for col, h := range headers {
+		s.table.SetCell(0, col, tview.NewTableCell(fmt.Sprintf("[::b]%s", h)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false))
+	}
+
+	s.rows = nil
+	switch s.params.SourceType {
+	case "Helm":
+		s.rows = append(s.rows, row{kind: rowValueFiles})
+		for i := range s.params.Helm {
+			s.rows = append(s.rows, row{kind: rowHelm, index: i})
+		}
+	case "Kustomize":
+		s.rows = append(s.rows, row{kind: rowNamePrefix}, row{kind: rowNameSuffix})
+		for i := range s.params.Images {
+			s.rows = append(s.rows, row{kind: rowImage, index: i})
+		}
+	default:
+		s.table.SetCell(1, 0, tview.NewTableCell(fmt.Sprintf("Source type %q has no parameters", s.params.SourceType)).
+			SetTextColor(tcell.ColorGray).
+			SetSelectable(false))
+		s.updateStatus()
+		return
+	}
+
+	for i, r := range s.rows {
+		name, value, def := s.describe(r)
+		// Highlight what differs from the defaults
+		color := tcell.ColorWhite
+		if value != def {
+			color = tcell.ColorYellow
+		}
+		s.table.SetCell(i+1, 0, tview.NewTableCell(tview.Escape(name)).SetTextColor(color))
+		s.table.SetCell(i+1, 1, tview.NewTableCell(tview.Escape(value)).SetTextColor(color).SetMaxWidth(60))
+		s.table.SetCell(i+1, 2, tview.NewTableCell(tview.Escape(def)).SetTextColor(tcell.ColorGray).
+			SetMaxWidth(60).SetExpansion(1))
+	}
+
+	if selected < 1 {
+		selected = 1
+	}
+	s.table.Select(min(selected, len(s.rows)), 0)
+	s.updateStatus()
+}
+
+// describe returns name, current value and default shown for r.
+func (s *ScreenAppParameters) describe(r row) (string, string, string) {
+	switch r.kind {
+	case rowValueFiles:
+		return "valueFiles", strings.Join(s.params.ValueFiles, ", "), ""
+	case rowHelm:
+		p := s.params.Helm[r.index]
+		return p.Name, p.Value, p.Default
+	case rowImage:
+		p := s.params.Images[r.index]
+		return p.Name, p.Value, p.Default
+	case rowNamePrefix:
+		return "namePrefix", s.params.NamePrefix, ""
+	default:
+		return "nameSuffix", s.params.NameSuffix, ""
+	}
+}
+
+func (s *ScreenAppParameters) updateStatus() {
+	overrides := 0
+	for _, params := range [][]argocd.Parameter{s.params.Helm, s.params.Images} {
+		for _, p := range params {
+			if p.Overridden {
+				overrides++
+			}
+		}
+	}
+	text := fmt.Sprintf("[gray]Overrides: [#63a0bf]%d", overrides)
+	if s.dirty {
+		text += " [yellow]| unsaved changes, press s to save[-]"
+	}
+	s.statusView.SetText(text)
+}
+
+func (s *ScreenAppParameters) selectedRow() (row, bool) {
+	selected, _ := s.table.GetSelection()
+	if selected < 1 || selected-1 >= len(s.rows) {
+		return row{}, false
+	}
+	return s.rows[selected-1], true
+}
+
+func (s *ScreenAppParameters) onKey(event *tcell.EventKey) *tcell.EventKey {
+	if event.Key() == tcell.KeyEnter {
+		if r, ok := s.selectedRow(); ok {
+			s.showEditDialog(r)
+		}
+		return nil
+	}
+	switch event.Rune() {
+	case 'b':
+		s.router.Back()
+		return nil
+	case 'r':
+		s.load()
+		return nil
+	case 'n':
+		if s.params.SourceType == "Helm" {
+			s.showNewParameterDialog()
+		}
+		return nil
+	case 'x':
+		if r, ok := s.selectedRow(); ok {
+			s.reset(r)
+		}
+		return nil
+	case 's':
+		s.save()
+		return nil
+	}
+	return event
+}
+
+func (s *ScreenAppParameters) showEditDialog(r row) {
+	name, value, _ := s.describe(r)
+	label := "Value"
+	if r.kind == rowValueFiles {
+		label = "Files (comma separated)"
+	}
+	s.showForm(fmt.Sprintf("Edit %s", name), []string{label}, []string{value}, func(values []string) {
+		s.set(r, values[0])
+	})
+}
+
+func (s *ScreenAppParameters) showNewParameterDialog() {
+	s.showForm("New Helm parameter", []string{"Name", "Value"}, []string{"", ""}, func(values []string) {
+		name := strings.TrimSpace(values[0])
+		if name == "" {
+			return
+		}
+		for i, p := range s.params.Helm {
+			if p.Name == name {
+				s.set(row{kind: rowHelm, index: i}, values[1])
+				return
+			}
+		}
+		s.params.Helm = append(s.params.Helm, argocd.Parameter{Name: name, Value: values[1], Overridden: true})
+		s.dirty = true
+		s.fillTable()
+		s.table.Select(len(s.rows), 0)
+	})
+}
+
+func (s *ScreenAppParameters) showForm(title string, labels, values []string, onSubmit func([]string)) {
+	textColor := tcell.NewHexColor(0x00bebe)
+
+	form := tview.NewForm().
+		SetFieldBackgroundColor(tcell.NewHexColor(0x1a1a1a)).
+		SetFieldTextColor(tcell.ColorWhite).
+		SetLabelColor(textColor).
+		SetButtonBackgroundColor(tcell.NewHexColor(0x017be9)).
+		SetButtonTextColor(tcell.ColorWhite)
+	form.SetBackgroundColor(tcell.NewHexColor(0x000000)).
+		SetBorderColor(tcell.NewHexColor(0x63a0bf)).
+		SetBorder(true).
+		SetTitle(" " + title + " ").
+		SetTitleAlign(tview.AlignCenter).
+		SetTitleColor(textColor)
+
+	for i := range labels {
+		i := i
+		form.AddInputField(labels[i], values[i], 50, nil, func(text string) {
+			values[i] = text
+		})
+	}
+	closeForm := func() {
+		s.router.CloseOverlay(s.pages)
+		s.app.SetFocus(s.table)
+	}
+	form.AddButton("OK", func() {
+		closeForm()
+		onSubmit(values)
+	})
+	form.AddButton("Cancel", closeForm)
+	form.SetButtonsAlign(tview.AlignCenter)
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			closeForm()
+			return nil
+		}
+		return event
+	})
+
+	s.router.ShowOverlay(components.Centered(form, 80, 5+2*len(labels)))
+}
+
+func (s *ScreenAppParameters) set(r row, value string) {
+	switch r.kind {
+	case rowValueFiles:
+		s.params.ValueFiles = nil
+		for _, file := range strings.Split(value, ",") {
+			if file = strings.TrimSpace(file); file != "" {
+				s.params.ValueFiles = append(s.params.ValueFiles, file)
+			}
+		}
+	case rowHelm:
+		s.params.Helm[r.index].Value = value
+		s.params.Helm[r.index].Overridden = true
+	case rowImage:
+		s.params.Images[r.index].Value = value
+		s.params.Images[r.index].Overridden = true
+	case rowNamePrefix:
+		s.params.NamePrefix = value
+	case rowNameSuffix:
+		s.params.NameSuffix = value
+	}
+	s.dirty = true
+	s.fillTable()
+}
+
+// reset drops the override of r so the default applies again.
+func (s *ScreenAppParameters) reset(r row) {
+	switch r.kind {
+	case rowHelm:
+		s.params.Helm[r.index].Value = s.params.Helm[r.index].Default
+		s.params.Helm[r.index].Overridden = false
+		s.params.Helm[r.index].ForceString = false
+	case rowImage:
+		s.params.Images[r.index].Value = s.params.Images[r.index].Default
+		s.params.Images[r.index].Overridden = false
+	default:
+		s.set(r, "")
+		return
+	}
+	s.dirty = true
+	s.fillTable()
+}
+
+// save writes the overrides to the spec after showing the resulting diff.
+func (s *ScreenAppParameters) save() {
+	if !s.dirty {
+		s.statusView.SetText("[gray]No changes to save[-]")
+		return
+	}
+	spec, err := s.client.GetAppSpec(s.appName)
+	if err != nil {
+		s.showError(err)
+		return
+	}
+	updated, err := argocd.SpecWithParameters(spec.YAML, s.params)
+	if err != nil {
+		s.showError(err)
+		return
+	}
+	unified := diff.Unified("spec", "spec", spec.YAML, updated, 3)
+	if unified == "" {
+		s.dirty = false
+		s.updateStatus()
+		return
+	}
+
+	dialog := components.DiffConfirmModal(fmt.Sprintf("Update parameters of %s?", s.appName), unified,
+		func() {
+			s.router.CloseOverlay(s.pages)
+			s.app.SetFocus(s.table)
+			err := s.client.UpdateAppSpec(s.appName, argocd.AppSpec{YAML: updated, ResourceVersion: spec.ResourceVersion})
+			if errors.Is(err, argocd.ErrConflict) {
+				s.statusView.SetText("[red]The application changed meanwhile, save again to apply on top of it[-]")
+				return
+			}
+			if err != nil {
+				s.showError(err)
+				return
+			}
+			s.load()
+			s.statusView.SetText(fmt.Sprintf("[green]Parameters of %s saved[-]", s.appName))
+		},
+		func() {
+			s.router.CloseOverlay(s.pages)
+			s.app.SetFocus(s.table)
+		},
+	)
+	s.router.ShowOverlay(dialog)
+}
+
+func (s *ScreenAppParameters) showError(err error) {
+	modal := components.ErrorModal(
+		fmt.Sprintf("Error saving parameters of %s:", s.appName),
+		err.Error(),
+		func() { s.app.SetRoot(s.pages, true) },
+	)
+	s.app.SetRoot(modal, true)
+}
+
+func (s *ScreenAppParameters) Name() string {
+	return "ApplicationParameters"
+}
@@ -17,6 +17,7 @@ type Footer struct {
 	shortcutKeyColor tcell.Color
 	app              *tview.Application
 	resourceCount    int
+	live             bool
 	ticker           *time.Ticker
 	done             chan bool
 }
@@ -95,8 +96,19 @@ func (f *Footer) UpdateResourceCount(count int) {
 	}
 	timeStr = strings.Replace(timeStr, "nd", suffix, 1)
 
-	f.infoView.SetText(fmt.Sprintf("[gray]Total resources: [#63a0bf]%d[gray] | [#ffffff]%s",
-		count, timeStr))
+	mode := "[red]disconnected"
+	if f.live {
+		mode = "[green]live"
+	}
+
+	f.infoView.SetText(fmt.Sprintf("%s[gray] | Total resources: [#63a0bf]%d[gray] | [#ffffff]%s",
+		mode, count, timeStr))
+}
+
+// SetLive shows whether the resource tree stream is connected.
+func (f *Footer) SetLive(live bool) {
+	f.live = live
+	f.UpdateResourceCount(f.resourceCount)
 }
 
 func (f *Footer) GetView() tview.Primitive {
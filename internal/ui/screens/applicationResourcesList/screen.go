@@ -1,9 +1,11 @@
 package applicationResourcesList
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Jack200062/ArguTUI/internal/transport/argocd"
@@ -11,26 +13,46 @@ import (
 	"github.com/Jack200062/ArguTUI/internal/ui/common"
 	"github.com/Jack200062/ArguTUI/internal/ui/components"
 	"github.com/Jack200062/ArguTUI/internal/ui/filters"
+	"github.com/Jack200062/ArguTUI/internal/ui/screens/operationProgress"
+	"github.com/Jack200062/ArguTUI/internal/ui/screens/podLogs"
+	"github.com/Jack200062/ArguTUI/internal/ui/screens/resourceDiff"
+	"github.com/Jack200062/ArguTUI/internal/ui/screens/resourceEvents"
+	"github.com/Jack200062/ArguTUI/internal/ui/screens/resourceManifest"
 	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
 type TreeResource struct {
+	Group      string
+	Version    string
 	Kind       string
 	Name       string
 	Health     string
 	SyncStatus string
 	Namespace  string
+	UID        string
 
 	Children []*TreeResource
 	Expanded bool
 	Depth    int
 	IsLast   bool
+	// Marked resources are synced selectively
+	Marked bool
+	// Missing resources are in git but not in the cluster, extraneous ones
+	// are in the cluster but not in git and RequiresPruning
+	Missing         bool
+	RequiresPruning bool
+	// Orphaned resources live in the destination namespace without being
+	// part of the application
+	Orphaned bool
 	// Cached lower-cased concatenation for search
 	SearchIndex string
 }
 
+// orphanedGroupKind is the kind of the root grouping the orphaned resources.
+const orphanedGroupKind = "Orphaned resources"
+
 type TreeLineInfo struct {
 	LineChars []rune
 }
@@ -38,7 +60,7 @@ type TreeLineInfo struct {
 type ScreenAppResourcesList struct {
 	app          *tview.Application
 	instanceInfo *common.InstanceInfo
-	client       *argocd.ArgoCdClient
+	client       argocd.Backend
 	router       *ui.Router
 
 	table     *tview.Table
@@ -53,6 +75,27 @@ type ScreenAppResourcesList struct {
 	cachedFlattened  []*TreeResource
 	selectedAppName  string
 	allExpanded      bool
+	searchQuery      string
+	// searchMode outlives the search bar, which Init rebuilds
+	searchMode components.SearchMode
+	// queryBeforeSearch is restored when the search bar is left with Esc
+	queryBeforeSearch string
+	searchTimer       *time.Timer
+	// statuses is the sync state of the managed resources, applied to every
+	// tree update
+	statuses []argocd.ResourceStatus
+	// tree is the last resource tree received, rebuilt when showOrphaned changes
+	tree         *v1alpha1.ApplicationTree
+	showOrphaned bool
+
+	watchCancel context.CancelFunc
+	// treeLive is whether the tree stream is connected
+	treeLive bool
+	// statusMu guards the scheduling of sync status fetches, which run off
+	// the UI goroutine
+	statusMu        sync.Mutex
+	statusPending   bool
+	lastStatusFetch time.Time
 
 	originalNodes map[string]*TreeResource
 
@@ -74,7 +117,7 @@ func New(
 	appSyncStatus string,
 	r *ui.Router,
 	instanceInfo *common.InstanceInfo,
-	client *argocd.ArgoCdClient,
+	client argocd.Backend,
 ) *ScreenAppResourcesList {
 	instanceInfo = instanceInfo.WithAppInfo(selectedAppName, appHealthStatus, appSyncStatus)
 
@@ -108,7 +151,7 @@ func collapseFully(node *TreeResource) {
 }
 
 func getNodeKey(node *TreeResource) string {
-	return fmt.Sprintf("%s|%s|%s", node.Kind, node.Namespace, node.Name)
+	return fmt.Sprintf("%s|%s|%s|%s", node.Group, node.Kind, node.Namespace, node.Name)
 }
 
 func markLastNodes(resources []*TreeResource) {
@@ -154,6 +197,12 @@ func flattenResourcesWithLines(resources []*TreeResource, depth int, parentLineI
 func (s *ScreenAppResourcesList) extractRootKindFilters() map[string]bool {
 	rootKindTypes := make(map[string]bool)
 	for _, root := range s.rootResources {
+		if isOrphanedGroup(root) {
+			for _, orphan := range root.Children {
+				rootKindTypes[orphan.Kind] = true
+			}
+			continue
+		}
 		rootKindTypes[root.Kind] = true
 	}
 	return rootKindTypes
@@ -168,6 +217,7 @@ func (s *ScreenAppResourcesList) Init() tview.Primitive {
 
 	s.topBar = NewTopBar(s.instanceInfo, s.selectedAppName, backgroundColor, shortcutKeyColor, textColor)
 	s.footer = NewFooter(s.app, backgroundColor, shortcutKeyColor)
+	s.footer.live = s.treeLive
 	s.tableView = NewTableView(s.selectedAppName, textColor, borderColor, backgroundColor, selectedBgColor)
 
 	topBarPrimitive := s.topBar.Init()
@@ -175,6 +225,11 @@ func (s *ScreenAppResourcesList) Init() tview.Primitive {
 
 	s.searchBar = components.NewSimpleSearchBar("🔍 ", 0)
 	s.initLiveSearch()
+	s.searchBar.Mode = s.searchMode
+	s.searchBar.EnableModes(func(mode components.SearchMode) {
+		s.searchMode = mode
+		s.filterResources(s.searchBar.InputField.GetText())
+	})
 	s.searchBar.InputField.SetDoneFunc(s.searchDone)
 
 	s.table = s.tableView.Init()
@@ -202,19 +257,40 @@ func (s *ScreenAppResourcesList) Init() tview.Primitive {
 
 	s.table.SetInputCapture(s.onTableKey)
 
-	if err := s.buildTreeFromResourceTree(); err != nil {
-		s.showToast(fmt.Sprintf("Error building tree: %v", err), 3*time.Second)
+	// Init runs again when coming back from a sub-screen or dialog; the tree
+	// is only loaded once so that marks and collapsed nodes are kept
+	if s.tree == nil {
+		if err := s.buildTreeFromResourceTree(); err != nil {
+			s.showToast(fmt.Sprintf("Error building tree: %v", err), 3*time.Second)
+		}
 	}
 
-	s.visibleResources = s.cachedFlattened
+	s.refreshFilterOptions()
+
+	if s.searchQuery != "" {
+		s.filterResources(s.searchQuery)
+	} else {
+		s.visibleResources = s.cachedFlattened
+		s.fillTableTreeMode()
+	}
+	s.startTreeWatch()
+	return s.pages
+}
 
+// refreshFilterOptions offers the kinds and statuses present in the tree in
+// the filter menu.
+func (s *ScreenAppResourcesList) refreshFilterOptions() {
 	rootKindTypes := s.extractRootKindFilters()
 
+	namespaces := make(map[string]bool)
 	healthStatuses := make(map[string]bool)
 	syncStatuses := make(map[string]bool)
 	var collectStatuses func([]*TreeResource)
 	collectStatuses = func(nodes []*TreeResource) {
 		for _, node := range nodes {
+			if node.Namespace != "" {
+				namespaces[node.Namespace] = true
+			}
 			if node.Health != "" {
 				healthStatuses[node.Health] = true
 			}
@@ -238,51 +314,216 @@ func (s *ScreenAppResourcesList) Init() tview.Primitive {
 	}
 	sort.Strings(syncStatusList)
 
-	s.filterManager = filters.NewResourceFilterManager(s.app, s.pages)
-	s.filterManager.SetFilterChangedHandler(s.onFiltersChanged)
 	s.filterManager.ExtractKindsFromResources(rootKindTypes)
+	s.filterManager.ExtractNamespacesFromResources(namespaces)
 	s.filterManager.SetHealthStatuses(healthStatusList)
 	s.filterManager.SetSyncStatuses(syncStatusList)
+}
 
-	s.fillTableTreeMode()
-	return s.pages
+// statusRefreshInterval is the least time between two fetches of the sync
+// statuses. A rollout sends many tree updates in a short time.
+const statusRefreshInterval = 3 * time.Second
+
+// startTreeWatch keeps the tree in sync with the WatchResourceTree stream.
+func (s *ScreenAppResourcesList) startTreeWatch() {
+	if s.watchCancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.watchCancel = cancel
+
+	// dropped is only used by the stream goroutine
+	dropped := false
+	go s.client.WatchResourceTree(ctx, s.selectedAppName,
+		func(tree *v1alpha1.ApplicationTree) {
+			s.app.QueueUpdateDraw(func() {
+				if ctx.Err() != nil {
+					return
+				}
+				s.applyTreeUpdate(tree)
+			})
+			// A changed tree usually means a changed sync state
+			s.scheduleStatusRefresh(ctx)
+		},
+		func(connected bool, err error) {
+			refetch := connected && dropped
+			dropped = !connected
+			s.app.QueueUpdateDraw(func() {
+				if ctx.Err() != nil {
+					return
+				}
+				s.treeLive = connected
+				s.footer.SetLive(connected)
+			})
+			if !refetch {
+				return
+			}
+			// Catch up on what changed while the stream was down
+			tree, err := s.client.GetResourceTree(s.selectedAppName)
+			if err != nil {
+				return
+			}
+			s.app.QueueUpdateDraw(func() {
+				if ctx.Err() == nil {
+					s.applyTreeUpdate(tree)
+				}
+			})
+			s.scheduleStatusRefresh(ctx)
+		},
+	)
 }
 
-func (s *ScreenAppResourcesList) onFiltersChanged(activeFilters []filters.FilterState) {
-	var kindFilter, healthFilter, syncFilter string
+// scheduleStatusRefresh fetches the sync statuses at most once every
+// statusRefreshInterval. Tree updates arriving while a fetch is scheduled are
+// covered by it.
+func (s *ScreenAppResourcesList) scheduleStatusRefresh(ctx context.Context) {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+	if s.statusPending {
+		return
+	}
+	s.statusPending = true
 
-	for _, filter := range activeFilters {
-		switch filter.Type {
-		case filters.ResourceKindFilter:
-			kindFilter = filter.Value
-		case filters.HealthFilter:
-			healthFilter = filter.Value
-		case filters.SyncFilter:
-			syncFilter = filter.Value
+	delay := max(time.Until(s.lastStatusFetch.Add(statusRefreshInterval)), 0)
+	time.AfterFunc(delay, func() {
+		s.statusMu.Lock()
+		s.statusPending = false
+		s.lastStatusFetch = time.Now()
+		s.statusMu.Unlock()
+
+		if ctx.Err() != nil {
+			return
+		}
+		statuses, err := s.client.GetResourceStatuses(s.selectedAppName)
+		if err != nil {
+			return
 		}
+		s.app.QueueUpdateDraw(func() {
+			if ctx.Err() != nil || s.tree == nil {
+				return
+			}
+			s.statuses = statuses
+			s.applyTreeUpdate(s.tree)
+		})
+	})
+}
+
+func (s *ScreenAppResourcesList) stopTreeWatch() {
+	if s.watchCancel != nil {
+		s.watchCancel()
+		s.watchCancel = nil
+	}
+}
+
+// applyTreeUpdate merges a fresh tree snapshot into the existing one. Nodes that
+// are still present keep their identity and expansion state, so the view does
+// not jump while a rollout is progressing.
+func (s *ScreenAppResourcesList) applyTreeUpdate(tree *v1alpha1.ApplicationTree) {
+	var selectedKey string
+	if row, _ := s.table.GetSelection(); row > 0 && row-1 < len(s.visibleResources) {
+		selectedKey = getNodeKey(s.visibleResources[row-1])
 	}
 
-	if kindFilter != "" {
-		s.filterResourcesByKind(kindFilter)
-	} else if healthFilter != "" || syncFilter != "" {
-		s.filterResourcesByStatus(healthFilter, syncFilter)
+	s.tree = tree
+	s.rootResources = s.mergeNodes(s.buildRoots(tree))
+	markLastNodes(s.rootResources)
+	s.buildOriginalNodesMap()
+	s.cachedFlattened = flattenResourcesWithLines(s.rootResources, 0, nil)
+
+	if s.searchQuery != "" {
+		s.filterResources(s.searchQuery)
 	} else {
-		s.visibleResources = s.cachedFlattened
+		s.onFiltersChanged(s.filterManager.Filters)
+	}
+
+	if selectedKey == "" {
+		return
+	}
+	for i, node := range s.visibleResources {
+		if getNodeKey(node) == selectedKey {
+			s.table.Select(i+1, 0)
+			return
+		}
+	}
+}
+
+func (s *ScreenAppResourcesList) mergeNodes(nodes []*TreeResource) []*TreeResource {
+	merged := make([]*TreeResource, 0, len(nodes))
+	for _, node := range nodes {
+		children := s.mergeNodes(node.Children)
+		existing, ok := s.originalNodes[getNodeKey(node)]
+		if !ok {
+			node.Children = children
+			merged = append(merged, node)
+			continue
+		}
+		existing.UID = node.UID
+		existing.Health = node.Health
+		existing.SyncStatus = node.SyncStatus
+		existing.Missing = node.Missing
+		existing.RequiresPruning = node.RequiresPruning
+		existing.Orphaned = node.Orphaned
+		existing.SearchIndex = node.SearchIndex
+		existing.Children = children
+		merged = append(merged, existing)
+	}
+	return merged
+}
+
+// onFiltersChanged shows the resources matching every filtered category, and
+// any of the values selected within a category. Kinds select root resources
+// with their subtree; namespace and statuses then narrow the flattened tree.
+func (s *ScreenAppResourcesList) onFiltersChanged(activeFilters []filters.FilterState) {
+	kinds := filterSet(activeFilters, filters.ResourceKindFilter)
+	namespaces := filterSet(activeFilters, filters.NamespaceFilter)
+	healths := filterSet(activeFilters, filters.HealthFilter)
+	syncs := filterSet(activeFilters, filters.SyncFilter)
+
+	s.visibleResources = s.cachedFlattened
+	if len(kinds) > 0 {
+		s.filterResourcesByKind(kinds)
+	}
+	if len(namespaces) > 0 || len(healths) > 0 || len(syncs) > 0 {
+		s.filterResourcesByStatus(namespaces, healths, syncs)
 	}
 
 	s.fillTableTreeMode()
 }
 
-func (s *ScreenAppResourcesList) filterResourcesByKind(kindFilter string) {
-	if kindFilter == "" {
-		s.visibleResources = s.cachedFlattened
-		return
+// filterSet returns the lower-cased values of filterType, nil when unfiltered.
+func filterSet(activeFilters []filters.FilterState, filterType filters.FilterType) map[string]bool {
+	var set map[string]bool
+	for _, value := range filters.FilterValues(activeFilters, filterType) {
+		if set == nil {
+			set = make(map[string]bool)
+		}
+		set[strings.ToLower(value)] = true
 	}
+	return set
+}
 
+func (s *ScreenAppResourcesList) filterResourcesByKind(kinds map[string]bool) {
 	var filteredRoots []*TreeResource
 
 	for _, root := range s.rootResources {
-		if root.Kind == kindFilter {
+		if isOrphanedGroup(root) {
+			// Keep the orphans of the kinds under their group
+			groupCopy := *root
+			groupCopy.Expanded = true
+			groupCopy.Children = nil
+			for _, orphan := range root.Children {
+				if kinds[strings.ToLower(orphan.Kind)] {
+					orphanCopy := *orphan
+					groupCopy.Children = append(groupCopy.Children, &orphanCopy)
+				}
+			}
+			if len(groupCopy.Children) > 0 {
+				markLastNodes(groupCopy.Children)
+				filteredRoots = append(filteredRoots, &groupCopy)
+			}
+			continue
+		}
+		if kinds[strings.ToLower(root.Kind)] {
 			rootCopy := *root
 			rootCopy.Expanded = true
 			filteredRoots = append(filteredRoots, &rootCopy)
@@ -292,27 +533,34 @@ func (s *ScreenAppResourcesList) filterResourcesByKind(kindFilter string) {
 	if len(filteredRoots) > 0 {
 		s.visibleResources = flattenResourcesWithLines(filteredRoots, 0, nil)
 	} else {
-		s.showToast(fmt.Sprintf("No root resources of type %s found", kindFilter), 2*time.Second)
-		s.filterManager.SetFilter(filters.ResourceKindFilter, "")
-		s.visibleResources = s.cachedFlattened
+		selected := s.filterManager.GetFilterValues(filters.ResourceKindFilter)
+		s.showToast(fmt.Sprintf("No root resources of type %s found", strings.Join(selected, ", ")), 2*time.Second)
+		s.visibleResources = nil
 	}
 }
 
-func (s *ScreenAppResourcesList) filterResourcesByStatus(healthFilter, syncFilter string) {
-	if healthFilter == "" && syncFilter == "" {
-		s.cachedFlattened = flattenResourcesWithLines(s.rootResources, 0, nil)
-		s.visibleResources = s.cachedFlattened
-		return
-	}
-
-	allNodes := s.cachedFlattened
+// filterResourcesByStatus narrows the visible resources. The header of the
+// orphaned resources stays as long as one of them matches.
+func (s *ScreenAppResourcesList) filterResourcesByStatus(namespaces, healths, syncs map[string]bool) {
 	var filtered []*TreeResource
-
-	for _, node := range allNodes {
-		healthMatch := healthFilter == "" || strings.EqualFold(node.Health, healthFilter)
-		syncMatch := syncFilter == "" || strings.EqualFold(node.SyncStatus, syncFilter)
-
-		if healthMatch && syncMatch {
+	var orphanedGroup *TreeResource
+	for _, node := range s.visibleResources {
+		if isOrphanedGroup(node) {
+			orphanedGroup = node
+			continue
+		}
+		if node.Depth == 0 {
+			orphanedGroup = nil
+		}
+		namespaceMatch := namespaces == nil || namespaces[strings.ToLower(node.Namespace)]
+		healthMatch := healths == nil || healths[strings.ToLower(node.Health)]
+		syncMatch := syncs == nil || syncs[strings.ToLower(node.SyncStatus)]
+
+		if namespaceMatch && healthMatch && syncMatch {
+			if orphanedGroup != nil {
+				filtered = append(filtered, orphanedGroup)
+				orphanedGroup = nil
+			}
 			filtered = append(filtered, node)
 		}
 	}
@@ -339,12 +587,11 @@ func (s *ScreenAppResourcesList) toggleExpansionAll() {
 }
 
 func (s *ScreenAppResourcesList) initLiveSearch() {
-	var debounceTimer *time.Timer
 	s.searchBar.InputField.SetChangedFunc(func(text string) {
-		if debounceTimer != nil {
-			debounceTimer.Stop()
+		if s.searchTimer != nil {
+			s.searchTimer.Stop()
 		}
-		debounceTimer = time.AfterFunc(500*time.Millisecond, func() {
+		s.searchTimer = time.AfterFunc(500*time.Millisecond, func() {
 			s.app.QueueUpdateDraw(func() {
 				s.filterResources(text)
 			})
@@ -352,57 +599,83 @@ func (s *ScreenAppResourcesList) initLiveSearch() {
 	})
 }
 
+// showSearchBar opens the search row as an overlay, so that the global key
+// bindings leave 'q' and Esc to the input.
 func (s *ScreenAppResourcesList) showSearchBar() {
+	s.queryBeforeSearch = s.searchQuery
+	s.searchBar.InputField.SetText(s.searchQuery)
 	s.grid.RemoveItem(s.table)
-	s.searchBar.InputField.SetText("")
-
 	s.grid.SetRows(3, 1, -1, 1)
 	s.grid.AddItem(s.searchBar.InputField, 1, 0, 1, 1, 0, 0, false)
 	s.grid.AddItem(s.table, 2, 0, 1, 1, 0, 0, true)
+	s.router.ShowOverlay(s.pages)
 	s.app.SetFocus(s.searchBar.InputField)
 }
 
 func (s *ScreenAppResourcesList) hideSearchBar() {
+	if s.searchTimer != nil {
+		s.searchTimer.Stop()
+	}
 	s.grid.RemoveItem(s.searchBar.InputField)
 	s.grid.RemoveItem(s.table)
 	s.grid.SetRows(3, 0, 1)
 	s.grid.AddItem(s.table, 1, 0, 1, 1, 0, 0, true)
+	s.router.CloseOverlay(s.pages)
 	s.app.SetFocus(s.table)
 }
 
-// Восстановленный метод для обработки завершения поиска
+// searchDone applies the query on Enter, and restores the one the search
+// started from on Esc.
 func (s *ScreenAppResourcesList) searchDone(key tcell.Key) {
-	if key == tcell.KeyEnter {
-		query := s.searchBar.InputField.GetText()
-		s.filterResources(query)
+	switch key {
+	case tcell.KeyEnter:
 		s.hideSearchBar()
-		s.app.SetFocus(s.table)
+		s.filterResources(s.searchBar.InputField.GetText())
+	case tcell.KeyEscape:
+		s.hideSearchBar()
+		s.filterResources(s.queryBeforeSearch)
 	}
 }
 
 func (s *ScreenAppResourcesList) filterResources(query string) {
+	s.searchQuery = query
 	if query == "" {
-		// Без сети: восстановить видимый список из уже загруженного дерева
+		// Restore the list from the loaded tree, without a request
 		s.visibleResources = s.cachedFlattened
 		s.fillTableTreeMode()
 		return
 	}
 
-	lq := strings.ToLower(query)
+	// An invalid regex keeps the previous results
+	matcher, err := components.NewMatcher(s.searchMode, query)
+	if err != nil {
+		s.searchBar.InputField.SetFieldTextColor(tcell.ColorRed)
+		return
+	}
+	s.searchBar.InputField.SetFieldTextColor(tcell.ColorWhite)
 
 	// Используем уже имеющееся дерево вместо повторного сетевого запроса
 	allNodes := s.cachedFlattened
 	var filtered []*TreeResource
+	matches := make(map[*TreeResource][]int)
+	scores := make(map[*TreeResource]int)
 	for _, n := range allNodes {
 		// Используем предрасчитанный индекс
-		if strings.Contains(n.SearchIndex, lq) {
+		if score, indexes, ok := matcher.Match(n.SearchIndex); ok {
 			filtered = append(filtered, n)
+			matches[n] = indexes
+			scores[n] = score
 		}
 	}
+	if s.searchMode == components.SearchFuzzy {
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return scores[filtered[i]] > scores[filtered[j]]
+		})
+	}
 
 	s.footer.UpdateResourceCount(len(filtered))
 
-	s.tableView.FillTableWithSearch(filtered)
+	s.tableView.FillTableWithSearch(filtered, matches)
 }
 
 func (s *ScreenAppResourcesList) buildTreeFromResourceTree() error {
@@ -410,22 +683,45 @@ func (s *ScreenAppResourcesList) buildTreeFromResourceTree() error {
 	if err != nil {
 		return err
 	}
-	s.rootResources = buildTreeFromNodes(appTree.Nodes)
+	// The tree is still worth showing without sync statuses
+	statuses, statusErr := s.client.GetResourceStatuses(s.selectedAppName)
+	s.statusMu.Lock()
+	s.lastStatusFetch = time.Now()
+	s.statusMu.Unlock()
+	s.statuses = statuses
+	s.tree = appTree
+	s.rootResources = s.buildRoots(appTree)
 	markLastNodes(s.rootResources)
 	s.buildOriginalNodesMap()
 	// Обновить кэш развёрнутого списка
 	s.cachedFlattened = flattenResourcesWithLines(s.rootResources, 0, nil)
-	return nil
+	return statusErr
+}
+
+func statusKey(group, kind, namespace, name string) string {
+	return group + "|" + kind + "|" + namespace + "|" + name
 }
 
-func buildTreeFromNodes(nodes []v1alpha1.ResourceNode) []*TreeResource {
+// buildTreeFromNodes links the nodes to their parents and applies the sync
+// state of the managed resources. Nodes that are not managed, such as Pods
+// of a Deployment, have no sync status. Missing resources have no node and
+// are added as roots.
+func buildTreeFromNodes(nodes []v1alpha1.ResourceNode, statuses []argocd.ResourceStatus) []*TreeResource {
+	statusMap := make(map[string]argocd.ResourceStatus, len(statuses))
+	for _, st := range statuses {
+		statusMap[statusKey(st.Group, st.Kind, st.Namespace, st.Name)] = st
+	}
+
 	resourceMap := make(map[string]*TreeResource)
 	for i := range nodes {
 		n := &nodes[i]
 		tr := &TreeResource{
+			Group:     n.Group,
+			Version:   n.Version,
 			Kind:      n.Kind,
 			Name:      n.Name,
 			Namespace: n.Namespace,
+			UID:       n.UID,
 			Expanded:  true,
 			Children:  []*TreeResource{},
 		}
@@ -434,9 +730,13 @@ func buildTreeFromNodes(nodes []v1alpha1.ResourceNode) []*TreeResource {
 		} else {
 			tr.Health = "Unknown"
 		}
-		tr.SyncStatus = "Synced"
-		// Precompute search index
-		tr.SearchIndex = strings.ToLower(tr.Kind + " " + tr.Name + " " + tr.Namespace + " " + tr.Health + " " + tr.SyncStatus)
+		key := statusKey(n.Group, n.Kind, n.Namespace, n.Name)
+		if st, ok := statusMap[key]; ok {
+			tr.SyncStatus = st.Status
+			tr.RequiresPruning = st.RequiresPruning
+			delete(statusMap, key)
+		}
+		tr.SearchIndex = searchIndex(tr)
 		resourceMap[n.UID] = tr
 	}
 
@@ -457,15 +757,109 @@ func buildTreeFromNodes(nodes []v1alpha1.ResourceNode) []*TreeResource {
 			roots = append(roots, resourceMap[n.UID])
 		}
 	}
+
+	// Keep the order of the managed resources for the missing ones
+	for _, st := range statuses {
+		if _, ok := statusMap[statusKey(st.Group, st.Kind, st.Namespace, st.Name)]; !ok || !st.Missing {
+			continue
+		}
+		tr := &TreeResource{
+			Group:      st.Group,
+			Kind:       st.Kind,
+			Name:       st.Name,
+			Namespace:  st.Namespace,
+			Health:     "Missing",
+			SyncStatus: st.Status,
+			Missing:    true,
+			Expanded:   true,
+			Children:   []*TreeResource{},
+		}
+		tr.SearchIndex = searchIndex(tr)
+		roots = append(roots, tr)
+	}
 	return roots
 }
 
+// buildRoots builds the resource tree of the application. Orphaned nodes are
+// grouped under a separate root when showOrphaned is set.
+func (s *ScreenAppResourcesList) buildRoots(tree *v1alpha1.ApplicationTree) []*TreeResource {
+	roots := buildTreeFromNodes(tree.Nodes, s.statuses)
+	if s.showOrphaned && len(tree.OrphanedNodes) > 0 {
+		roots = append(roots, newOrphanedGroup(buildTreeFromNodes(tree.OrphanedNodes, nil)))
+	}
+	return roots
+}
+
+func newOrphanedGroup(children []*TreeResource) *TreeResource {
+	var mark func([]*TreeResource)
+	mark = func(nodes []*TreeResource) {
+		for _, node := range nodes {
+			node.Orphaned = true
+			node.SyncStatus = "Orphaned"
+			node.SearchIndex = searchIndex(node)
+			mark(node.Children)
+		}
+	}
+	mark(children)
+
+	group := &TreeResource{
+		Kind:       orphanedGroupKind,
+		SyncStatus: "Orphaned",
+		Orphaned:   true,
+		Expanded:   true,
+		Children:   children,
+	}
+	group.SearchIndex = searchIndex(group)
+	return group
+}
+
+func isOrphanedGroup(node *TreeResource) bool {
+	return node.Orphaned && node.Kind == orphanedGroupKind
+}
+
+// toggleOrphaned shows or hides the orphaned resources. ArgoCD only reports
+// them when orphaned resource monitoring is enabled in the project.
+func (s *ScreenAppResourcesList) toggleOrphaned() {
+	if s.tree == nil {
+		return
+	}
+	s.showOrphaned = !s.showOrphaned
+	s.applyTreeUpdate(s.tree)
+	s.refreshFilterOptions()
+
+	switch count := len(s.tree.OrphanedNodes); {
+	case !s.showOrphaned:
+		s.showToast("Orphaned resources hidden", 2*time.Second)
+	case count == 0:
+		s.showToast("No orphaned resources, is orphaned resource monitoring enabled in the project?", 3*time.Second)
+	default:
+		s.showToast(fmt.Sprintf("Showing %d orphaned resources", count), 2*time.Second)
+	}
+}
+
+// searchIndex is the lower-cased text searched by filterResources.
+func searchIndex(tr *TreeResource) string {
+	text := tr.Kind + " " + tr.Name + " " + tr.Namespace + " " + tr.Health + " " + tr.SyncStatus
+	if tr.Missing {
+		text += " missing"
+	}
+	if tr.RequiresPruning {
+		text += " prune"
+	}
+	if tr.Orphaned {
+		text += " orphaned"
+	}
+	return strings.ToLower(text)
+}
+
 func (s *ScreenAppResourcesList) onTableKey(event *tcell.EventKey) *tcell.EventKey {
 	switch event.Rune() {
 	case 'q':
+		s.stopTreeWatch()
 		s.app.Stop()
 		return nil
 	case 'b':
+		s.stopTreeWatch()
 		s.router.Back()
 		return nil
 	case 't':
@@ -484,9 +878,50 @@ func (s *ScreenAppResourcesList) onTableKey(event *tcell.EventKey) *tcell.EventK
 	case 'd', 'D':
 		s.filterManager.ToggleFilter(filters.ResourceKindFilter, "Deployment")
 		return nil
-	case 's', 'S':
+	case 's':
 		s.filterManager.ToggleFilter(filters.ResourceKindFilter, "Service")
 		return nil
+	case 'S':
+		s.showSyncOptions()
+		return nil
+	case ' ':
+		s.toggleMarkSelected()
+		return nil
+	case 'M':
+		s.markOutOfSync()
+		return nil
+	case 'U':
+		s.clearMarks()
+		return nil
+	case 'v':
+		s.showSelectedDiff()
+		return nil
+	case 'V':
+		s.showDiffScreen(nil)
+		return nil
+	case 'l':
+		s.showLogs()
+		return nil
+	case 'y':
+		s.showManifest()
+		return nil
+	case 'a':
+		s.showActions()
+		return nil
+	case 'x':
+		s.confirmDeleteResource()
+		return nil
+	case 'e':
+		if ref, ok := s.selectedRef(); ok {
+			s.showEvents(&ref)
+		}
+		return nil
+	case 'E':
+		s.showEvents(nil)
+		return nil
+	case 'w':
+		s.showOperation()
+		return nil
 	case 'i', 'I':
 		s.filterManager.ToggleFilter(filters.ResourceKindFilter, "Ingress")
 		return nil
@@ -503,9 +938,12 @@ func (s *ScreenAppResourcesList) onTableKey(event *tcell.EventKey) *tcell.EventK
 	case 'p', 'P':
 		s.filterManager.ToggleFilter(filters.HealthFilter, "Progressing")
 		return nil
-	case 'o', 'O':
+	case 'o':
 		s.filterManager.ToggleFilter(filters.SyncFilter, "OutOfSync")
 		return nil
+	case 'O':
+		s.toggleOrphaned()
+		return nil
 	}
 
 	if event.Key() == tcell.KeyEnter {
@@ -574,6 +1012,323 @@ func (s *ScreenAppResourcesList) showToast(message string, duration time.Duratio
 	}()
 }
 
+func (s *ScreenAppResourcesList) toggleMarkSelected() {
+	row, _ := s.table.GetSelection()
+	if row < 1 || row-1 >= len(s.visibleResources) {
+		return
+	}
+	node, ok := s.originalNodes[getNodeKey(s.visibleResources[row-1])]
+	if !ok {
+		return
+	}
+	if !s.isRootResource(node) {
+		s.showToast(fmt.Sprintf("%s/%s is not managed by %s directly", node.Kind, node.Name, s.selectedAppName), 2*time.Second)
+		return
+	}
+	node.Marked = !node.Marked
+	s.refreshVisible()
+	if row+1 < s.table.GetRowCount() {
+		s.table.Select(row+1, 0)
+	}
+}
+
+func (s *ScreenAppResourcesList) markOutOfSync() {
+	count := 0
+	for _, root := range s.rootResources {
+		if strings.EqualFold(root.SyncStatus, "OutOfSync") {
+			root.Marked = true
+			count++
+		}
+	}
+	s.refreshVisible()
+	s.showToast(fmt.Sprintf("Marked %d OutOfSync resources", count), 2*time.Second)
+}
+
+func (s *ScreenAppResourcesList) clearMarks() {
+	for _, node := range s.originalNodes {
+		node.Marked = false
+	}
+	s.refreshVisible()
+}
+
+// isRootResource reports whether node is a top-level resource of the app.
+// Only those can be passed to a selective sync.
+func (s *ScreenAppResourcesList) isRootResource(node *TreeResource) bool {
+	if node.Orphaned {
+		return false
+	}
+	for _, root := range s.rootResources {
+		if root == node {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *ScreenAppResourcesList) markedResources() []argocd.SyncResource {
+	var marked []argocd.SyncResource
+	for _, root := range s.rootResources {
+		if root.Marked {
+			marked = append(marked, argocd.SyncResource{
+				Group:     root.Group,
+				Kind:      root.Kind,
+				Namespace: root.Namespace,
+				Name:      root.Name,
+			})
+		}
+	}
+	return marked
+}
+
+// refreshVisible redraws the table keeping the current search or filters.
+func (s *ScreenAppResourcesList) refreshVisible() {
+	row, _ := s.table.GetSelection()
+	if s.searchQuery != "" {
+		s.filterResources(s.searchQuery)
+	} else {
+		s.onFiltersChanged(s.filterManager.Filters)
+	}
+	s.table.Select(row, 0)
+}
+
+func (s *ScreenAppResourcesList) showSyncOptions() {
+	opts := components.LastSyncOptions(s.instanceInfo.Name)
+	opts.Resources = s.markedResources()
+	dialog := components.SyncOptionsModal(s.selectedAppName, opts,
+		func(opts argocd.SyncOptions) {
+			components.RememberSyncOptions(s.instanceInfo.Name, opts)
+			s.router.CloseOverlay(s.pages)
+			s.syncApplication(opts)
+		},
+		func() {
+			s.router.CloseOverlay(s.pages)
+		},
+	)
+	s.router.ShowOverlay(dialog)
+}
+
+func (s *ScreenAppResourcesList) syncApplication(opts argocd.SyncOptions) {
+	if err := s.client.SyncApp(s.selectedAppName, opts); err != nil {
+		modal := components.ErrorModal(
+			fmt.Sprintf("Error syncing app %s:", s.selectedAppName),
+			err.Error(),
+			func() { s.router.CloseOverlay(s.pages) },
+		)
+		s.router.ShowOverlay(modal)
+		return
+	}
+	if opts.DryRun {
+		s.showToast(fmt.Sprintf("Dry run of %s completed", s.selectedAppName), 2*time.Second)
+		return
+	}
+	if len(opts.Resources) > 0 {
+		s.clearMarks()
+		s.showToast(fmt.Sprintf("Sync of %d resources in %s started", len(opts.Resources), s.selectedAppName), 2*time.Second)
+		return
+	}
+	s.showToast(fmt.Sprintf("App %s sync started", s.selectedAppName), 2*time.Second)
+}
+
+func (s *ScreenAppResourcesList) showSelectedDiff() {
+	row, _ := s.table.GetSelection()
+	if row < 1 || row-1 >= len(s.visibleResources) {
+		return
+	}
+	node := s.visibleResources[row-1]
+	if isOrphanedGroup(node) {
+		return
+	}
+	s.showDiffScreen(&resourceDiff.Target{
+		Group:     node.Group,
+		Kind:      node.Kind,
+		Namespace: node.Namespace,
+		Name:      node.Name,
+	})
+}
+
+// openScreen shows screen in place of the resources. The tree watch is paused
+// meanwhile, Init resumes it on the way back.
+func (s *ScreenAppResourcesList) openScreen(screen ui.Screen) {
+	s.stopTreeWatch()
+	s.router.ReplaceScreen(screen)
+	s.router.SwitchTo(screen.Name())
+}
+
+// showDiffScreen opens the diff of target, or of all OutOfSync resources when target is nil.
+func (s *ScreenAppResourcesList) showDiffScreen(target *resourceDiff.Target) {
+	diffScreen := resourceDiff.New(s.app, s.router, s.instanceInfo, s.client, s.selectedAppName, target)
+	s.openScreen(diffScreen)
+}
+
+// logKinds are the resource kinds ArgoCD can stream pod logs for.
+var logKinds = map[string]bool{
+	"Pod":         true,
+	"Deployment":  true,
+	"StatefulSet": true,
+	"DaemonSet":   true,
+	"ReplicaSet":  true,
+	"Job":         true,
+	"Rollout":     true,
+}
+
+func (s *ScreenAppResourcesList) showLogs() {
+	ref, ok := s.selectedRef()
+	if !ok {
+		return
+	}
+	if !logKinds[ref.Kind] {
+		s.showToast(fmt.Sprintf("%s has no pod logs", ref.Kind), 2*time.Second)
+		return
+	}
+	logsScreen := podLogs.New(s.app, s.router, s.instanceInfo, s.client, s.selectedAppName, ref)
+	s.openScreen(logsScreen)
+}
+
+func (s *ScreenAppResourcesList) showManifest() {
+	ref, ok := s.selectedRef()
+	if !ok {
+		return
+	}
+	manifestScreen := resourceManifest.New(s.app, s.router, s.instanceInfo, s.client, s.selectedAppName, ref)
+	s.openScreen(manifestScreen)
+}
+
+// showEvents opens the events of ref, or of the application when ref is nil.
+func (s *ScreenAppResourcesList) showEvents(ref *argocd.ResourceRef) {
+	eventsScreen := resourceEvents.New(s.app, s.router, s.instanceInfo, s.client, s.selectedAppName, ref)
+	s.openScreen(eventsScreen)
+}
+
+// showOperation follows the sync operation of the application.
+func (s *ScreenAppResourcesList) showOperation() {
+	operationScreen := operationProgress.New(s.app, s.router, s.instanceInfo, s.client, s.selectedAppName)
+	s.openScreen(operationScreen)
+}
+
+// showActions lists the resource actions ArgoCD offers for the selected node.
+func (s *ScreenAppResourcesList) showActions() {
+	ref, ok := s.selectedRef()
+	if !ok {
+		return
+	}
+	actions, err := s.client.ListResourceActions(s.selectedAppName, ref)
+	if err != nil {
+		modal := components.ErrorModal(
+			fmt.Sprintf("Error listing actions of %s/%s:", ref.Kind, ref.Name),
+			err.Error(),
+			func() { s.router.CloseOverlay(s.pages) },
+		)
+		s.router.ShowOverlay(modal)
+		return
+	}
+	if len(actions) == 0 {
+		s.showToast(fmt.Sprintf("No actions available for %s/%s", ref.Kind, ref.Name), 2*time.Second)
+		return
+	}
+
+	list := tview.NewList().ShowSecondaryText(false)
+	list.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Actions: %s/%s ", ref.Kind, ref.Name)).
+		SetBorderColor(tcell.NewHexColor(0x63a0bf))
+	list.SetBackgroundColor(tcell.NewHexColor(0x000000))
+	list.SetSelectedBackgroundColor(tcell.NewHexColor(0x373737))
+	width := 40
+	for _, action := range actions {
+		action := action
+		label := action.DisplayName
+		if label == "" {
+			label = action.Name
+		}
+		if action.Disabled {
+			label = "[gray]" + label + " (disabled)[-]"
+		}
+		width = max(width, len(label)+6)
+		list.AddItem(label, "", 0, func() {
+			if action.Disabled {
+				return
+			}
+			s.router.CloseOverlay(s.pages)
+			s.confirmAction(ref, action.Name)
+		})
+	}
+	list.SetDoneFunc(func() {
+		s.router.CloseOverlay(s.pages)
+	})
+	s.router.ShowOverlay(components.Centered(list, width, len(actions)+2))
+}
+
+func (s *ScreenAppResourcesList) confirmAction(ref argocd.ResourceRef, action string) {
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Run action %s on %s %s/%s?", action, ref.Kind, ref.Namespace, ref.Name)).
+		AddButtons([]string{"Yes", "No"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			s.router.CloseOverlay(s.pages)
+			if buttonIndex != 0 {
+				return
+			}
+			if err := s.client.RunResourceAction(s.selectedAppName, ref, action); err != nil {
+				errorModal := components.ErrorModal(
+					fmt.Sprintf("Error running action %s on %s/%s:", action, ref.Kind, ref.Name),
+					err.Error(),
+					func() { s.router.CloseOverlay(s.pages) },
+				)
+				s.router.ShowOverlay(errorModal)
+				return
+			}
+			s.showToast(fmt.Sprintf("Action %s on %s/%s started", action, ref.Kind, ref.Name), 2*time.Second)
+		})
+	modal.SetBackgroundColor(tcell.ColorDarkRed)
+	s.router.ShowOverlay(modal)
+}
+
+// confirmDeleteResource deletes the selected resource once confirmed. The
+// tree watch shows it going away.
+func (s *ScreenAppResourcesList) confirmDeleteResource() {
+	ref, ok := s.selectedRef()
+	if !ok {
+		return
+	}
+	dialog := components.DeleteResourceModal(ref,
+		func(opts argocd.DeleteResourceOptions) {
+			s.router.CloseOverlay(s.pages)
+			if err := s.client.DeleteResource(s.selectedAppName, ref, opts); err != nil {
+				errorModal := components.ErrorModal(
+					fmt.Sprintf("Error deleting %s/%s:", ref.Kind, ref.Name),
+					err.Error(),
+					func() { s.router.CloseOverlay(s.pages) },
+				)
+				s.router.ShowOverlay(errorModal)
+				return
+			}
+			s.showToast(fmt.Sprintf("Deleting %s %s/%s", ref.Kind, ref.Namespace, ref.Name), 2*time.Second)
+		},
+		func() {
+			s.router.CloseOverlay(s.pages)
+		},
+	)
+	s.router.ShowOverlay(dialog)
+}
+
+// selectedRef returns the resource under the cursor.
+func (s *ScreenAppResourcesList) selectedRef() (argocd.ResourceRef, bool) {
+	row, _ := s.table.GetSelection()
+	if row < 1 || row-1 >= len(s.visibleResources) {
+		return argocd.ResourceRef{}, false
+	}
+	node := s.visibleResources[row-1]
+	if isOrphanedGroup(node) {
+		return argocd.ResourceRef{}, false
+	}
+	return argocd.ResourceRef{
+		Group:     node.Group,
+		Version:   node.Version,
+		Kind:      node.Kind,
+		Namespace: node.Namespace,
+		Name:      node.Name,
+		UID:       node.UID,
+	}, true
+}
+
 func (s *ScreenAppResourcesList) Name() string {
 	return "ApplicationResourcesList"
 }
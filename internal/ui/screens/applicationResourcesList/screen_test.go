@@ -0,0 +1,277 @@
+package applicationResourcesList
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd"
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd/fake"
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd/uitest"
+	"github.com/Jack200062/ArguTUI/internal/ui/common"
+	"github.com/Jack200062/ArguTUI/internal/ui/filters"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func node(kind, name, uid string, parents ...string) v1alpha1.ResourceNode {
+	n := v1alpha1.ResourceNode{
+		ResourceRef: v1alpha1.ResourceRef{
+			Group:     "apps",
+			Version:   "v1",
+			Kind:      kind,
+			Namespace: "default",
+			Name:      name,
+			UID:       uid,
+		},
+		Health: &v1alpha1.HealthStatus{Status: "Healthy"},
+	}
+	if kind == "Service" || kind == "Pod" {
+		n.Group = ""
+	}
+	for _, parent := range parents {
+		n.ParentRefs = append(n.ParentRefs, v1alpha1.ResourceRef{UID: parent})
+	}
+	return n
+}
+
+// apiTree is a Deployment with its ReplicaSet and Pod, next to a Service.
+func apiTree() *v1alpha1.ApplicationTree {
+	return &v1alpha1.ApplicationTree{Nodes: []v1alpha1.ResourceNode{
+		node("Deployment", "api", "deploy"),
+		node("ReplicaSet", "api-5d4f", "rs", "deploy"),
+		node("Pod", "api-5d4f-x2k", "pod", "rs"),
+		node("Service", "api", "svc"),
+	}}
+}
+
+func newResourcesScreen(t *testing.T, b *fake.Backend) (*tview.Application, *ScreenAppResourcesList) {
+	t.Helper()
+	app, router := uitest.Start(t)
+	instance := common.NewInstanceInfo("https://argocd.example.com", "test")
+	s := New(app, nil, "api", "Healthy", "Synced", router, instance, b)
+	router.ReplaceScreen(s)
+	if err := router.SwitchTo(s.Name()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(s.stopTreeWatch)
+	return app, s
+}
+
+// visibleKinds lists the kind/name of the rows shown in the table.
+func visibleKinds(s *ScreenAppResourcesList) []string {
+	names := make([]string, len(s.visibleResources))
+	for i, tr := range s.visibleResources {
+		names[i] = tr.Kind + "/" + tr.Name
+	}
+	return names
+}
+
+func TestTreeIsLoadedFromTheBackend(t *testing.T) {
+	b := fake.New(argocd.Application{Name: "api"})
+	b.SetResourceTree("api", apiTree())
+	app, s := newResourcesScreen(t, b)
+
+	uitest.Eventually(t, app, func() bool { return len(s.visibleResources) == 4 })
+	uitest.OnUI(app, func() {
+		want := []string{"Deployment/api", "ReplicaSet/api-5d4f", "Pod/api-5d4f-x2k", "Service/api"}
+		got := visibleKinds(s)
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("visible resources = %v, want %v", got, want)
+				break
+			}
+		}
+	})
+}
+
+func TestTreeUpdateKeepsMarksAndCollapsedNodes(t *testing.T) {
+	b := fake.New(argocd.Application{Name: "api"})
+	b.SetResourceTree("api", apiTree())
+	app, s := newResourcesScreen(t, b)
+	uitest.Eventually(t, app, func() bool { return len(s.visibleResources) == 4 })
+
+	uitest.OnUI(app, func() {
+		s.table.Select(4, 0) // Service/api
+		s.toggleMarkSelected()
+		s.originalNodes[getNodeKey(s.rootResources[0])].Expanded = false
+		s.cachedFlattened = flattenResourcesWithLines(s.rootResources, 0, nil)
+		s.refreshVisible()
+	})
+
+	// A rollout replaces the ReplicaSet and its Pod
+	rolled := apiTree()
+	rolled.Nodes[1] = node("ReplicaSet", "api-7c9b", "rs2", "deploy")
+	rolled.Nodes[2] = node("Pod", "api-7c9b-q8z", "pod2", "rs2")
+	b.Script(fake.Transition{App: argocd.Application{Name: "api"}, Tree: rolled})
+	b.Step()
+
+	uitest.Eventually(t, app, func() bool {
+		return s.tree != nil && s.tree.Nodes[1].Name == "api-7c9b"
+	})
+	uitest.OnUI(app, func() {
+		if got := visibleKinds(s); len(got) != 2 {
+			t.Errorf("visible resources = %v, want the collapsed Deployment and the Service", got)
+		}
+		marked := s.markedResources()
+		if len(marked) != 1 || marked[0].Kind != "Service" {
+			t.Errorf("marked resources = %+v, want the Service", marked)
+		}
+	})
+}
+
+func TestDeletedResourceLeavesTheTree(t *testing.T) {
+	b := fake.New(argocd.Application{Name: "api"})
+	b.SetResourceTree("api", apiTree())
+	app, s := newResourcesScreen(t, b)
+	uitest.Eventually(t, app, func() bool { return len(s.visibleResources) == 4 })
+
+	var ref argocd.ResourceRef
+	uitest.OnUI(app, func() {
+		s.table.Select(1, 0) // Deployment/api
+		ref, _ = s.selectedRef()
+	})
+	if err := b.DeleteResource("api", ref, argocd.DeleteResourceOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The dependents of the Deployment go with it
+	uitest.Eventually(t, app, func() bool {
+		got := visibleKinds(s)
+		return len(got) == 1 && got[0] == "Service/api"
+	})
+}
+
+func TestMarkOutOfSyncMarksTheModifiedResources(t *testing.T) {
+	b := fake.New(argocd.Application{Name: "api"})
+	b.SetResourceTree("api", apiTree())
+	b.SetResourceDiffs("api", []argocd.ResourceDiff{
+		{Group: "apps", Kind: "Deployment", Namespace: "default", Name: "api", LiveState: "replicas: 1", TargetState: "replicas: 2", Modified: true},
+		{Kind: "Service", Namespace: "default", Name: "api", LiveState: "port: 80", TargetState: "port: 80"},
+	})
+	app, s := newResourcesScreen(t, b)
+	uitest.Eventually(t, app, func() bool { return len(s.visibleResources) == 4 })
+
+	uitest.OnUI(app, func() {
+		s.markOutOfSync()
+		marked := s.markedResources()
+		if len(marked) != 1 || marked[0].Kind != "Deployment" || marked[0].Name != "api" {
+			t.Errorf("marked resources = %+v, want the Deployment", marked)
+		}
+	})
+}
+
+func TestTreeUpdatesDoNotRefetchStatusesEachTime(t *testing.T) {
+	b := fake.New(argocd.Application{Name: "api"})
+	b.SetResourceTree("api", apiTree())
+	app, s := newResourcesScreen(t, b)
+	uitest.Eventually(t, app, func() bool { return len(s.visibleResources) == 4 })
+
+	for i := 0; i < 5; i++ {
+		b.Script(fake.Transition{App: argocd.Application{Name: "api"}, Tree: apiTree()})
+		b.Step()
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	fetches := 0
+	for _, call := range b.Calls() {
+		if call.Method == "GetResourceStatuses" {
+			fetches++
+		}
+	}
+	// Only the initial load; the updates wait for statusRefreshInterval
+	if fetches != 1 {
+		t.Errorf("GetResourceStatuses called %d times, want 1", fetches)
+	}
+}
+
+func TestSameNameInAnotherGroupIsAnotherNode(t *testing.T) {
+	tree := apiTree()
+	legacy := node("Ingress", "api", "ing-ext")
+	legacy.Group = "extensions"
+	current := node("Ingress", "api", "ing-net")
+	current.Group = "networking.k8s.io"
+	tree.Nodes = append(tree.Nodes, legacy, current)
+	b := fake.New(argocd.Application{Name: "api"})
+	b.SetResourceTree("api", tree)
+	app, s := newResourcesScreen(t, b)
+	uitest.Eventually(t, app, func() bool { return len(s.visibleResources) == 6 })
+
+	uitest.OnUI(app, func() {
+		s.table.Select(5, 0) // the extensions Ingress
+		s.toggleMarkSelected()
+		marked := s.markedResources()
+		if len(marked) != 1 || marked[0].Group != "extensions" {
+			t.Errorf("marked resources = %+v, want the extensions Ingress only", marked)
+		}
+	})
+}
+
+func TestKindFilterWithoutMatchKeepsTheFilter(t *testing.T) {
+	b := fake.New(argocd.Application{Name: "api"})
+	b.SetResourceTree("api", apiTree())
+	app, s := newResourcesScreen(t, b)
+	uitest.Eventually(t, app, func() bool { return len(s.visibleResources) == 4 })
+
+	uitest.OnUI(app, func() {
+		s.filterManager.Filters = []filters.FilterState{{Type: filters.ResourceKindFilter, Value: "CronJob"}}
+		s.onFiltersChanged(s.filterManager.Filters)
+		if got := visibleKinds(s); len(got) != 0 {
+			t.Errorf("visible resources = %v, want none", got)
+		}
+		if got := s.filterManager.GetFilterValues(filters.ResourceKindFilter); len(got) != 1 || got[0] != "CronJob" {
+			t.Errorf("kind filter = %v, want CronJob", got)
+		}
+	})
+}
+
+func TestStatusFilterKeepsTheOrphanedHeader(t *testing.T) {
+	tree := apiTree()
+	orphan := node("ConfigMap", "leftover", "cm")
+	orphan.Health = &v1alpha1.HealthStatus{Status: "Degraded"}
+	tree.OrphanedNodes = []v1alpha1.ResourceNode{orphan, node("Secret", "old", "secret")}
+	b := fake.New(argocd.Application{Name: "api"})
+	b.SetResourceTree("api", tree)
+	app, s := newResourcesScreen(t, b)
+	uitest.Eventually(t, app, func() bool { return len(s.visibleResources) == 4 })
+
+	uitest.OnUI(app, func() {
+		s.toggleOrphaned()
+		s.filterManager.Filters = []filters.FilterState{{Type: filters.HealthFilter, Value: "Degraded"}}
+		s.onFiltersChanged(s.filterManager.Filters)
+		got := visibleKinds(s)
+		if len(got) != 2 || got[0] != orphanedGroupKind+"/" || got[1] != "ConfigMap/leftover" {
+			t.Errorf("visible resources = %v, want the orphaned header and the ConfigMap", got)
+		}
+	})
+}
+
+func TestEscapeRestoresTheSearchQuery(t *testing.T) {
+	b := fake.New(argocd.Application{Name: "api"})
+	b.SetResourceTree("api", apiTree())
+	app, s := newResourcesScreen(t, b)
+	uitest.Eventually(t, app, func() bool { return len(s.visibleResources) == 4 })
+
+	uitest.OnUI(app, func() {
+		s.filterResources("service")
+		s.showSearchBar()
+		if !s.router.IsModalActive() {
+			t.Error("search bar is not an overlay")
+		}
+		s.searchBar.InputField.SetText("pod")
+		s.searchDone(tcell.KeyEscape)
+		if s.router.IsModalActive() {
+			t.Error("overlay still active after Esc")
+		}
+		if s.searchQuery != "service" {
+			t.Errorf("search query = %q, want service", s.searchQuery)
+		}
+
+		s.showSearchBar()
+		s.searchBar.InputField.SetText("pod")
+		s.searchDone(tcell.KeyEnter)
+		if s.router.IsModalActive() || s.searchQuery != "pod" {
+			t.Errorf("search query = %q after Enter, want pod", s.searchQuery)
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/Jack200062/ArguTUI/internal/ui/common"
+	"github.com/Jack200062/ArguTUI/internal/ui/components"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
@@ -68,36 +69,37 @@ func (t *TableView) FillTableWithTree(resources []*TreeResource, activeFilters s
 
 		treePrefix := generateTreePrefix(tr, lineInfo)
 		kindText := treePrefix + tr.Kind
+		if tr.Marked {
+			kindText = "✔ " + kindText
+		}
 
 		kindCell := tview.NewTableCell(kindText).SetExpansion(1)
 
 		t.table.SetCell(row, 0, kindCell)
-		t.table.SetCell(row, 1, tview.NewTableCell(tr.Name).SetExpansion(1))
+		t.table.SetCell(row, 1, tview.NewTableCell(nameText(tr)).SetExpansion(1))
 
 		healthCell := tview.NewTableCell(tr.Health).
 			SetExpansion(1).
 			SetTextColor(common.ColorForHealthStatus(tr.Health))
 		t.table.SetCell(row, 2, healthCell)
 
-		syncCell := tview.NewTableCell(tr.SyncStatus).
-			SetExpansion(1)
-		if tr.SyncStatus == "OutOfSync" {
-			syncCell.SetTextColor(tcell.ColorYellow)
-		} else {
-			syncCell.SetTextColor(tcell.ColorGreen)
-		}
-		t.table.SetCell(row, 3, syncCell)
+		t.table.SetCell(row, 3, t.syncCell(tr, nil))
 
 		t.table.SetCell(row, 4, tview.NewTableCell(tr.Namespace).SetExpansion(1))
 
 		rowColor := common.RowColorForStatuses(tr.Health, tr.SyncStatus)
+		if isOrphanedGroup(tr) {
+			rowColor = tcell.ColorOrange
+		}
 		common.SetRowColor(t.table, row, len(headers), rowColor)
 
 		row++
 	}
 }
 
-func (t *TableView) FillTableWithSearch(resources []*TreeResource) {
+// FillTableWithSearch shows resources flat, highlighting the offsets in
+// matches where the search matched their SearchIndex.
+func (t *TableView) FillTableWithSearch(resources []*TreeResource, matches map[*TreeResource][]int) {
 	t.table.Clear()
 	headers := []string{"Kind", "Name", "Health", "SyncStatus", "Namespace"}
 	for col, h := range headers {
@@ -112,34 +114,68 @@ func (t *TableView) FillTableWithSearch(resources []*TreeResource) {
 
 	row := 1
 	for _, tr := range resources {
-		kindCell := tview.NewTableCell(tr.Kind).SetExpansion(1)
+		// The fields in the order they are joined by searchIndex
+		split := components.SplitMatches(matches[tr], tr.Kind, tr.Name, tr.Namespace, tr.Health, tr.SyncStatus)
+		kindText := components.Highlight(tr.Kind, split[0])
+		if tr.Marked {
+			kindText = "✔ " + kindText
+		}
+		kindCell := tview.NewTableCell(kindText).SetExpansion(1)
 
+		nameCell := tview.NewTableCell(nameText(tr))
+		if !isOrphanedGroup(tr) {
+			nameCell.SetText(components.Highlight(tr.Name, split[1]))
+		}
 		t.table.SetCell(row, 0, kindCell)
-		t.table.SetCell(row, 1, tview.NewTableCell(tr.Name).SetExpansion(1))
+		t.table.SetCell(row, 1, nameCell.SetExpansion(1))
 
-		healthCell := tview.NewTableCell(tr.Health).
+		healthCell := tview.NewTableCell(components.Highlight(tr.Health, split[3])).
 			SetExpansion(1).
 			SetTextColor(common.ColorForHealthStatus(tr.Health))
 		t.table.SetCell(row, 2, healthCell)
 
-		syncCell := tview.NewTableCell(tr.SyncStatus).
-			SetExpansion(1)
-		if tr.SyncStatus == "OutOfSync" {
-			syncCell.SetTextColor(tcell.ColorYellow)
-		} else {
-			syncCell.SetTextColor(tcell.ColorGreen)
-		}
-		t.table.SetCell(row, 3, syncCell)
+		t.table.SetCell(row, 3, t.syncCell(tr, split[4]))
 
-		t.table.SetCell(row, 4, tview.NewTableCell(tr.Namespace).SetExpansion(1))
+		t.table.SetCell(row, 4, tview.NewTableCell(components.Highlight(tr.Namespace, split[2])).SetExpansion(1))
 
 		rowColor := common.RowColorForStatuses(tr.Health, tr.SyncStatus)
+		if isOrphanedGroup(tr) {
+			rowColor = tcell.ColorOrange
+		}
 		common.SetRowColor(t.table, row, len(headers), rowColor)
 
 		row++
 	}
 }
 
+// nameText is the name of tr, or the number of orphans in the orphaned group.
+func nameText(tr *TreeResource) string {
+	if isOrphanedGroup(tr) {
+		return fmt.Sprintf("%d not managed by the application", len(tr.Children))
+	}
+	return tr.Name
+}
+
+// syncCell shows the sync status with the missing and prune markers,
+// highlighting the search matches in the status. Resources that are not
+// managed by the application have no status.
+func (t *TableView) syncCell(tr *TreeResource, matched []int) *tview.TableCell {
+	text := components.Highlight(tr.SyncStatus, matched)
+	switch {
+	case tr.Missing:
+		text += " (missing)"
+	case tr.RequiresPruning:
+		text += " (requires pruning)"
+	}
+	cell := tview.NewTableCell(text).SetExpansion(1)
+	if tr.SyncStatus == "OutOfSync" {
+		cell.SetTextColor(tcell.ColorYellow)
+	} else {
+		cell.SetTextColor(tcell.ColorGreen)
+	}
+	return cell
+}
+
 func (t *TableView) GetTable() *tview.Table {
 	return t.table
 }
@@ -57,6 +57,19 @@ func (t *TopBar) Init() tview.Primitive {
 		"q": "Quit",
 	})
 
+	shortcutBar.AddGroup("Actions", map[string]string{
+		"S":     "Sync",
+		"Space": "Mark",
+		"v/V":   "Diff",
+		"l":     "Logs",
+		"y":     "YAML",
+		"a":     "Actions",
+		"x":     "Delete",
+		"O":     "Orphaned",
+		"e/E":   "Events",
+		"w":     "Operation",
+	})
+
 	shortcutBarPrimitive := shortcutBar.Init()
 
 	t.view = tview.NewFlex().
@@ -17,6 +17,7 @@ type Footer struct {
 	shortcutKeyColor tcell.Color
 	app              *tview.Application
 	lastRefreshTime  time.Time
+	live             bool
 	ticker           *time.Ticker
 	done             chan bool
 }
@@ -112,9 +113,22 @@ func (f *Footer) UpdateTimeInfo(lastRefreshTime time.Time) {
 	}
 	timeStr = strings.Replace(timeStr, "nd", suffix, 1)
 
+	mode := "[yellow]polling"
+	if f.live {
+		mode = "[green]live"
+	}
+
 	lastRefresh := f.getLastRefreshTime(lastRefreshTime)
-	f.timeView.SetText(fmt.Sprintf("[gray]Last refresh: [#63a0bf]%s[gray] | [#ffffff]%s",
-		lastRefresh, timeStr))
+	f.timeView.SetText(fmt.Sprintf("%s[gray] | Last update: [#63a0bf]%s[gray] | [#ffffff]%s",
+		mode, lastRefresh, timeStr))
+}
+
+// SetLive switches the refresh indicator between the Watch stream and polling.
+func (f *Footer) SetLive(live bool) {
+	f.live = live
+	if f.timeView != nil {
+		f.UpdateTimeInfo(f.lastRefreshTime)
+	}
 }
 
 func (f *Footer) getLastRefreshTime(lastRefreshTime time.Time) string {
@@ -0,0 +1,630 @@
+package applicationlist
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd"
+	"github.com/Jack200062/ArguTUI/internal/ui/components"
+)
+
+// The search bar takes a query such as
+//
+//	project:payments health:degraded sync!=synced name~^api- label:team=core age>7d
+//
+// Terms are ANDed; AND, OR, NOT and parentheses combine them. A field term
+// compares case-insensitively: ':' and '=' test equality, '~' matches a
+// regular expression and '!' negates either. Only age compares with < and >.
+// Words without a field match anywhere in the search mode of the bar.
+
+// queryFields are the field names, as completed in the search bar.
+var queryFields = []string{
+	"name", "project", "health", "sync", "namespace", "cluster",
+	"repo", "path", "chart", "revision", "label", "age",
+}
+
+var fieldAliases = map[string]string{
+	"proj": "project",
+	"ns":   "namespace",
+	"dest": "cluster",
+}
+
+var queryOperators = []string{"!=", "!~", ">=", "<=", ":", "=", "~", ">", "<"}
+
+// ageOperators are the operators age is completed with, and ageValues the
+// durations offered after them.
+var (
+	ageOperators = []string{">", ">=", "<", "<="}
+	ageValues    = []string{"1h", "1d", "7d", "30d"}
+)
+
+// QueryError is a parse error at a byte offset of the query.
+type QueryError struct {
+	Pos int
+	Msg string
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("col %d: %s", e.Pos+1, e.Msg)
+}
+
+type queryNode interface {
+	match(app *argocd.Application, now time.Time) bool
+}
+
+type andNode []queryNode
+
+func (n andNode) match(app *argocd.Application, now time.Time) bool {
+	for _, child := range n {
+		if !child.match(app, now) {
+			return false
+		}
+	}
+	return true
+}
+
+type orNode []queryNode
+
+func (n orNode) match(app *argocd.Application, now time.Time) bool {
+	for _, child := range n {
+		if child.match(app, now) {
+			return true
+		}
+	}
+	return false
+}
+
+type notNode struct {
+	node queryNode
+}
+
+func (n notNode) match(app *argocd.Application, now time.Time) bool {
+	return !n.node.match(app, now)
+}
+
+// textNode is a word without a field, searched in the whole application.
+type textNode struct {
+	matcher *components.Matcher
+}
+
+func (n textNode) match(app *argocd.Application, _ time.Time) bool {
+	_, _, ok := n.matcher.Match(app.SearchString())
+	return ok
+}
+
+// textTerms are the words of q to highlight; negated words never match.
+func textTerms(q queryNode) []textNode {
+	var children []queryNode
+	switch n := q.(type) {
+	case textNode:
+		return []textNode{n}
+	case andNode:
+		children = n
+	case orNode:
+		children = n
+	}
+	var terms []textNode
+	for _, child := range children {
+		terms = append(terms, textTerms(child)...)
+	}
+	return terms
+}
+
+type fieldNode struct {
+	field string
+	op    string
+	value string
+	re    *regexp.Regexp
+	age   time.Duration
+}
+
+func (n fieldNode) match(app *argocd.Application, now time.Time) bool {
+	if n.field == "age" {
+		if app.CreatedAt.IsZero() {
+			return false
+		}
+		age := now.Sub(app.CreatedAt)
+		switch n.op {
+		case ">":
+			return age > n.age
+		case ">=":
+			return age >= n.age
+		case "<":
+			return age < n.age
+		default:
+			return age <= n.age
+		}
+	}
+
+	matched := false
+	for _, value := range fieldValues(app, n.field) {
+		if n.re != nil {
+			matched = n.re.MatchString(value)
+		} else if n.field == "label" && !strings.Contains(n.value, "=") {
+			// label:team tests for the key
+			key, _, _ := strings.Cut(value, "=")
+			matched = strings.EqualFold(key, n.value)
+		} else {
+			matched = strings.EqualFold(value, n.value)
+		}
+		if matched {
+			break
+		}
+	}
+	if strings.HasPrefix(n.op, "!") {
+		return !matched
+	}
+	return matched
+}
+
+// fieldValues returns the values of field; a term matches when any does.
+func fieldValues(app *argocd.Application, field string) []string {
+	switch field {
+	case "name":
+		return []string{app.Name}
+	case "project":
+		return []string{app.Project}
+	case "health":
+		return []string{app.HealthStatus}
+	case "sync":
+		return []string{app.SyncStatus}
+	case "namespace":
+		return []string{app.DestNamespace}
+	case "cluster":
+		return []string{app.DestName, app.DestServer}
+	case "repo":
+		return []string{app.RepoURL}
+	case "path":
+		return []string{app.Path}
+	case "chart":
+		return []string{app.Chart}
+	case "revision":
+		return []string{app.TargetRevision}
+	case "label":
+		labels := make([]string, 0, len(app.Labels))
+		for key, value := range app.Labels {
+			labels = append(labels, key+"="+value)
+		}
+		sort.Strings(labels)
+		return labels
+	}
+	return nil
+}
+
+// parseQuery parses text into a query matching the applications, with words
+// matched in mode. An empty query yields a nil node.
+func parseQuery(text string, mode components.SearchMode) (queryNode, error) {
+	tokens, err := tokenizeQuery(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	p := &queryParser{tokens: tokens, end: len(text), mode: mode}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok, ok := p.peek(); ok {
+		return nil, &QueryError{Pos: tok.pos, Msg: fmt.Sprintf("unexpected %q", tok.text)}
+	}
+	return node, nil
+}
+
+// filterApplications returns the applications matching q, and by name the
+// offsets in SearchString where their words matched. Fuzzy matches are
+// ranked best first.
+func filterApplications(apps []argocd.Application, q queryNode, mode components.SearchMode) ([]argocd.Application, map[string][]int) {
+	now := time.Now()
+	terms := textTerms(q)
+	filtered := make([]argocd.Application, 0, len(apps))
+	matches := make(map[string][]int)
+	scores := make(map[string]int)
+	for i := range apps {
+		app := &apps[i]
+		if !q.match(app, now) {
+			continue
+		}
+		for _, term := range terms {
+			if score, indexes, ok := term.matcher.Match(app.SearchString()); ok {
+				scores[app.Name] += score
+				matches[app.Name] = append(matches[app.Name], indexes...)
+			}
+		}
+		filtered = append(filtered, *app)
+	}
+	if mode == components.SearchFuzzy && len(terms) > 0 {
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return scores[filtered[i].Name] > scores[filtered[j].Name]
+		})
+	}
+	return filtered, matches
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenLParen
+	tokenRParen
+	tokenAnd
+	tokenOr
+	tokenNot
+)
+
+type queryToken struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// tokenizeQuery splits text into words, parentheses and keywords. Quotes
+// keep spaces in a word, and parentheses opened within a word, as in a
+// regular expression, belong to it.
+func tokenizeQuery(text string) ([]queryToken, error) {
+	var tokens []queryToken
+	i := 0
+	for i < len(text) {
+		switch c := text[i]; {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(':
+			tokens = append(tokens, queryToken{kind: tokenLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, queryToken{kind: tokenRParen, text: ")", pos: i})
+			i++
+		default:
+			start := i
+			depth := 0
+			quoted := false
+		word:
+			for ; i < len(text); i++ {
+				switch text[i] {
+				case '"':
+					quoted = !quoted
+				case ' ', '\t':
+					if !quoted {
+						break word
+					}
+				case '(':
+					if !quoted {
+						depth++
+					}
+				case ')':
+					if !quoted {
+						if depth == 0 {
+							break word
+						}
+						depth--
+					}
+				}
+			}
+			if quoted {
+				return nil, &QueryError{Pos: start, Msg: "unterminated quote"}
+			}
+			tokens = append(tokens, wordToken(text[start:i], start))
+		}
+	}
+	return tokens, nil
+}
+
+func wordToken(word string, pos int) queryToken {
+	kind := tokenWord
+	switch strings.ToUpper(word) {
+	case "AND":
+		kind = tokenAnd
+	case "OR":
+		kind = tokenOr
+	case "NOT":
+		kind = tokenNot
+	}
+	return queryToken{kind: kind, text: word, pos: pos}
+}
+
+type queryParser struct {
+	tokens []queryToken
+	next   int
+	// end is the length of the query, where missing terms are reported
+	end  int
+	mode components.SearchMode
+}
+
+func (p *queryParser) peek() (queryToken, bool) {
+	if p.next >= len(p.tokens) {
+		return queryToken{}, false
+	}
+	return p.tokens[p.next], true
+}
+
+func (p *queryParser) parseOr() (queryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	nodes := orNode{left}
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.kind != tokenOr {
+			break
+		}
+		p.next++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, right)
+	}
+	if len(nodes) == 1 {
+		return left, nil
+	}
+	return nodes, nil
+}
+
+func (p *queryParser) parseAnd() (queryNode, error) {
+	var nodes andNode
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.kind == tokenOr || tok.kind == tokenRParen {
+			break
+		}
+		if tok.kind == tokenAnd {
+			if len(nodes) == 0 {
+				return nil, &QueryError{Pos: tok.pos, Msg: "AND needs a term on its left"}
+			}
+			p.next++
+			if next, ok := p.peek(); !ok || next.kind == tokenOr || next.kind == tokenRParen || next.kind == tokenAnd {
+				return nil, p.missingTerm("AND")
+			}
+			continue
+		}
+		node, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	switch len(nodes) {
+	case 0:
+		return nil, p.missingTerm("")
+	case 1:
+		return nodes[0], nil
+	}
+	return nodes, nil
+}
+
+func (p *queryParser) parseNot() (queryNode, error) {
+	tok, _ := p.peek()
+	if tok.kind == tokenNot {
+		p.next++
+		if next, ok := p.peek(); !ok || next.kind == tokenOr || next.kind == tokenRParen || next.kind == tokenAnd {
+			return nil, p.missingTerm("NOT")
+		}
+		node, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{node: node}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *queryParser) parsePrimary() (queryNode, error) {
+	tok, _ := p.peek()
+	p.next++
+	switch tok.kind {
+	case tokenLParen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing, ok := p.peek(); !ok || closing.kind != tokenRParen {
+			return nil, &QueryError{Pos: tok.pos, Msg: "missing closing parenthesis"}
+		}
+		p.next++
+		return node, nil
+	case tokenWord:
+		return parseTerm(tok.text, tok.pos, p.mode)
+	}
+	return nil, &QueryError{Pos: tok.pos, Msg: fmt.Sprintf("unexpected %q", tok.text)}
+}
+
+// missingTerm reports a term missing after keyword, or at the current token.
+func (p *queryParser) missingTerm(keyword string) error {
+	pos := p.end
+	if tok, ok := p.peek(); ok {
+		pos = tok.pos
+	}
+	if keyword == "" {
+		return &QueryError{Pos: pos, Msg: "expected a term"}
+	}
+	return &QueryError{Pos: pos, Msg: "expected a term after " + keyword}
+}
+
+// parseTerm parses a field term like health:degraded, or a bare word. A word
+// like https://host or team:core whose prefix is not a field is a bare word.
+func parseTerm(word string, pos int, mode components.SearchMode) (queryNode, error) {
+	name, op, value, ok := splitTerm(word)
+	field := strings.ToLower(name)
+	if alias, known := fieldAliases[field]; known {
+		field = alias
+	}
+	if strings.HasPrefix(word, `"`) || !ok || !isQueryField(field) {
+		matcher, err := components.NewMatcher(mode, unquote(word))
+		if err != nil {
+			return nil, &QueryError{Pos: pos, Msg: "invalid regular expression: " + err.Error()}
+		}
+		return textNode{matcher: matcher}, nil
+	}
+
+	valuePos := pos + len(name) + len(op)
+	value = unquote(value)
+	if value == "" {
+		return nil, &QueryError{Pos: valuePos, Msg: fmt.Sprintf("missing value after %s%s", name, op)}
+	}
+
+	node := fieldNode{field: field, op: op, value: value}
+	switch {
+	case field == "age":
+		if op != ">" && op != ">=" && op != "<" && op != "<=" {
+			return nil, &QueryError{Pos: pos + len(name), Msg: "age compares with >, >=, < or <="}
+		}
+		age, err := parseAge(value)
+		if err != nil {
+			return nil, &QueryError{Pos: valuePos, Msg: err.Error()}
+		}
+		node.age = age
+	case op == ">" || op == ">=" || op == "<" || op == "<=":
+		return nil, &QueryError{Pos: pos + len(name), Msg: fmt.Sprintf("%s does not compare with %s", field, op)}
+	case op == "~" || op == "!~":
+		re, err := regexp.Compile("(?i)" + value)
+		if err != nil {
+			return nil, &QueryError{Pos: valuePos, Msg: "invalid regular expression: " + err.Error()}
+		}
+		node.re = re
+	}
+	return node, nil
+}
+
+// splitTerm splits a word into field, operator and value when it starts with
+// letters followed by an operator.
+func splitTerm(word string) (field, op, value string, ok bool) {
+	end := strings.IndexFunc(word, func(r rune) bool { return !unicode.IsLetter(r) })
+	if end <= 0 {
+		return "", "", "", false
+	}
+	for _, candidate := range queryOperators {
+		if strings.HasPrefix(word[end:], candidate) {
+			return word[:end], candidate, word[end+len(candidate):], true
+		}
+	}
+	return "", "", "", false
+}
+
+func isQueryField(field string) bool {
+	for _, f := range queryFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && strings.HasPrefix(s, `"`) && strings.HasSuffix(s, `"`) {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// parseAge parses durations like 90m, 12h, 7d or 2w.
+func parseAge(value string) (time.Duration, error) {
+	units := map[byte]time.Duration{
+		'm': time.Minute,
+		'h': time.Hour,
+		'd': 24 * time.Hour,
+		'w': 7 * 24 * time.Hour,
+	}
+	unit, ok := units[value[len(value)-1]]
+	if !ok {
+		return 0, fmt.Errorf("invalid age %q, use a number with m, h, d or w", value)
+	}
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid age %q, use a number with m, h, d or w", value)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// completeQuery suggests completions of the last word of text: field names,
+// then the values of the field found in apps.
+func completeQuery(text string, apps []argocd.Application) []string {
+	start := strings.LastIndexAny(text, " \t(") + 1
+	word := text[start:]
+	if word == "" {
+		return nil
+	}
+
+	var candidates []string
+	name, op, partial, ok := splitTerm(word)
+	if !ok {
+		lower := strings.ToLower(word)
+		for _, field := range queryFields {
+			if !strings.HasPrefix(field, lower) {
+				continue
+			}
+			if field == "age" {
+				for _, ageOp := range ageOperators {
+					candidates = append(candidates, field+ageOp)
+				}
+				continue
+			}
+			candidates = append(candidates, field+":")
+		}
+	} else {
+		field := strings.ToLower(name)
+		if alias, ok := fieldAliases[field]; ok {
+			field = alias
+		}
+		if op == "~" || op == "!~" {
+			return nil
+		}
+		if field == "age" && !slices.Contains(ageOperators, op) {
+			// Only the comparisons apply to age
+			for _, ageOp := range ageOperators {
+				candidates = append(candidates, name+ageOp)
+			}
+		} else {
+			lower := strings.ToLower(unquote(partial))
+			for _, value := range knownValues(field, apps) {
+				if strings.HasPrefix(strings.ToLower(value), lower) {
+					if strings.ContainsAny(value, " \t()") {
+						value = `"` + value + `"`
+					}
+					candidates = append(candidates, name+op+value)
+				}
+			}
+		}
+	}
+
+	const maxEntries = 10
+	entries := make([]string, 0, maxEntries)
+	for _, candidate := range candidates {
+		// A complete word needs no drop-down
+		if candidate == word {
+			continue
+		}
+		entries = append(entries, text[:start]+candidate)
+		if len(entries) == maxEntries {
+			break
+		}
+	}
+	return entries
+}
+
+// knownValues returns the distinct values of field among apps, sorted, or
+// common durations for age.
+func knownValues(field string, apps []argocd.Application) []string {
+	if field == "age" {
+		return ageValues
+	}
+	seen := make(map[string]bool)
+	var values []string
+	for i := range apps {
+		for _, value := range fieldValues(&apps[i], field) {
+			if value != "" && !seen[value] {
+				seen[value] = true
+				values = append(values, value)
+			}
+		}
+	}
+	sort.Strings(values)
+	return values
+}
@@ -0,0 +1,186 @@
+package applicationlist
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd"
+	"github.com/Jack200062/ArguTUI/internal/ui/components"
+)
+
+// TestCompletionsParse checks that every completion is a valid query, or
+// completes further to valid queries, as a field name does to its values.
+func TestCompletionsParse(t *testing.T) {
+	apps := []argocd.Application{
+		{
+			Name: "api", Project: "payments", HealthStatus: "Healthy", SyncStatus: "Synced",
+			RepoURL: "https://git.example.com/api.git", Path: "deploy", TargetRevision: "main",
+			DestNamespace: "payments", DestServer: "https://kubernetes.default.svc",
+			Labels: map[string]string{"team": "core"},
+		},
+		{
+			Name: "web ui", Project: "frontend", HealthStatus: "Degraded", SyncStatus: "OutOfSync",
+			RepoURL: "https://charts.example.com", Chart: "web", TargetRevision: "1.2.0",
+			DestNamespace: "web", DestName: "in-cluster",
+		},
+	}
+
+	var check func(text string, depth int)
+	check = func(text string, depth int) {
+		for _, entry := range completeQuery(text, apps) {
+			if _, err := parseQuery(entry, components.SearchFuzzy); err == nil {
+				continue
+			}
+			if depth == 0 || len(completeQuery(entry, apps)) == 0 {
+				t.Errorf("completion %q of %q does not parse", entry, text)
+				continue
+			}
+			check(entry, depth-1)
+		}
+	}
+
+	for _, text := range []string{"a", "ag", "age", "age:", "age>", "age<=", "age>1", "p", "project:", "health:d", "name:", "sync!=", "x and n"} {
+		if len(completeQuery(text, apps)) == 0 {
+			t.Errorf("no completion for %q", text)
+		}
+		check(text, 1)
+	}
+}
+
+func TestAgeCompletesToOperatorsThenDurations(t *testing.T) {
+	got := completeQuery("age", nil)
+	want := []string{"age>", "age>=", "age<", "age<="}
+	if len(got) != len(want) {
+		t.Fatalf("completions of age = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("completions of age = %v, want %v", got, want)
+		}
+	}
+
+	got = completeQuery("health:ok age>", nil)
+	want = []string{"health:ok age>1h", "health:ok age>1d", "health:ok age>7d", "health:ok age>30d"}
+	if len(got) != len(want) {
+		t.Fatalf("completions of age> = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("completions of age> = %v, want %v", got, want)
+		}
+	}
+}
+
+func queryApps() []argocd.Application {
+	now := time.Now()
+	return []argocd.Application{
+		{
+			Name: "api", Project: "payments", HealthStatus: "Healthy", SyncStatus: "Synced",
+			Labels: map[string]string{"team": "core", "tier": "backend"}, CreatedAt: now.Add(-10 * 24 * time.Hour),
+		},
+		{
+			Name: "web", Project: "frontend", HealthStatus: "Degraded", SyncStatus: "OutOfSync",
+			Labels: map[string]string{"team": "web"}, CreatedAt: now.Add(-2 * time.Hour),
+		},
+		{
+			Name: "worker", Project: "payments", HealthStatus: "Healthy", SyncStatus: "OutOfSync",
+			CreatedAt: now.Add(-3 * 24 * time.Hour),
+		},
+	}
+}
+
+func TestFilterApplications(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"project:payments", []string{"api", "worker"}},
+		{"proj=PAYMENTS", []string{"api", "worker"}},
+		{"sync!=synced", []string{"web", "worker"}},
+		{"project:payments health:degraded", nil},
+		{"project:payments or health:degraded", []string{"api", "web", "worker"}},
+		{"project:payments and not sync:synced", []string{"worker"}},
+		{"not (name:api or name:web)", []string{"worker"}},
+		{"(name:api or name:web) sync:outofsync", []string{"web"}},
+		{"name~^w", []string{"web", "worker"}},
+		{"name!~^w", []string{"api"}},
+		{"label:team", []string{"api", "web"}},
+		{"label:team=core", []string{"api"}},
+		{"label:tier=core", nil},
+		{"label!=team", []string{"worker"}},
+		{"age>7d", []string{"api"}},
+		{"age<1d", []string{"web"}},
+		{"age>=1d age<=7d", []string{"worker"}},
+		{"work", []string{"worker"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := parseQuery(tt.query, components.SearchSubstring)
+			if err != nil {
+				t.Fatal(err)
+			}
+			filtered, _ := filterApplications(queryApps(), q, components.SearchSubstring)
+			var got []string
+			for _, app := range filtered {
+				got = append(got, app.Name)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("%q matches %v, want %v", tt.query, got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("%q matches %v, want %v", tt.query, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestUnknownFieldIsAWord(t *testing.T) {
+	for _, query := range []string{"team:core", "https://git.example.com", "foo=bar", `"health:ok"`} {
+		q, err := parseQuery(query, components.SearchSubstring)
+		if err != nil {
+			t.Errorf("parseQuery(%q) = %v", query, err)
+			continue
+		}
+		if _, ok := q.(textNode); !ok {
+			t.Errorf("parseQuery(%q) = %T, want a word", query, q)
+		}
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		pos   int
+	}{
+		{"health:", 7},
+		{"project:payments sync!=", 23},
+		{"age:7d", 3},
+		{"age>soon", 4},
+		{"name>api", 4},
+		{"name~(", 5},
+		{`"unterminated`, 0},
+		{"(health:ok", 0},
+		{"health:ok )", 10},
+		{"and health:ok", 0},
+		{"health:ok or", 12},
+		{"health:ok and or name:api", 14},
+		{"not", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := parseQuery(tt.query, components.SearchSubstring)
+			var qerr *QueryError
+			if !errors.As(err, &qerr) {
+				t.Fatalf("parseQuery(%q) error = %v, want a QueryError", tt.query, err)
+			}
+			if qerr.Pos != tt.pos {
+				t.Errorf("parseQuery(%q) error at %d (%s), want %d", tt.query, qerr.Pos, qerr.Msg, tt.pos)
+			}
+		})
+	}
+}
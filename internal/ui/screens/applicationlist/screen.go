@@ -1,6 +1,8 @@
 package applicationlist
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -11,30 +13,49 @@ import (
 	"github.com/Jack200062/ArguTUI/internal/ui/common"
 	"github.com/Jack200062/ArguTUI/internal/ui/components"
 	"github.com/Jack200062/ArguTUI/internal/ui/filters"
+	"github.com/Jack200062/ArguTUI/internal/ui/screens/applicationDetails"
+	"github.com/Jack200062/ArguTUI/internal/ui/screens/applicationParameters"
 	"github.com/Jack200062/ArguTUI/internal/ui/screens/applicationResourcesList"
+	"github.com/Jack200062/ArguTUI/internal/ui/screens/createApplication"
+	"github.com/Jack200062/ArguTUI/internal/ui/screens/operationProgress"
+	"github.com/Jack200062/ArguTUI/pkg/diff"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
+// pollInterval is used only while the application Watch stream is unavailable.
+const pollInterval = 60 * time.Second
+
 type ScreenAppList struct {
 	app          *tview.Application
 	instanceInfo *common.InstanceInfo
 	apps         []argocd.Application
-	client       *argocd.ArgoCdClient
+	client       argocd.Backend
 	router       *ui.Router
 
 	grid         *tview.Grid
 	table        *tview.Table
 	pages        *tview.Pages
 	searchBar    *components.SimpleSearchBar
+	searchRow    *tview.Flex
+	searchHint   *tview.TextView
 	filteredApps []argocd.Application
 
-	projectFilter   string
-	healthFilter    string
-	syncFilter      string
-	searchQuery     string
+	projectFilter string
+	healthFilter  string
+	syncFilter    string
+	searchQuery   string
+	// searchMode is kept here as the search bar is rebuilt by Init
+	searchMode components.SearchMode
+	// query is the last valid parse of searchQuery
+	query           queryNode
+	searchMatches   map[string][]int
 	lastRefreshTime time.Time
 
+	watchCancel     context.CancelFunc
+	streamConnected bool
+	streamDropped   bool
+
 	topBar    *TopBar
 	footer    *Footer
 	tableView *TableView
@@ -43,7 +64,7 @@ type ScreenAppList struct {
 
 func New(
 	app *tview.Application,
-	c *argocd.ArgoCdClient,
+	c argocd.Backend,
 	r *ui.Router,
 	instanceInfo *common.InstanceInfo,
 	apps []argocd.Application,
@@ -79,7 +100,7 @@ func (s *ScreenAppList) getApplicationStats() (healthy, degraded, outOfSync int)
 }
 
 func (s *ScreenAppList) Init() tview.Primitive {
-	s.startAutoRefresh()
+	s.startLiveUpdates()
 
 	textColor := tcell.NewHexColor(0x00bebe)        // table Title Color
 	backgroundColor := tcell.NewHexColor(0x000000)  // background Color
@@ -96,14 +117,25 @@ func (s *ScreenAppList) Init() tview.Primitive {
 	healthy, degraded, outOfSync := s.getApplicationStats()
 	s.topBar.UpdateStats(healthy, degraded, outOfSync)
 	footerPrimitive := s.footer.Init()
+	s.footer.SetLive(s.streamConnected)
 	s.footer.UpdateTimeInfo(s.lastRefreshTime)
 
 	s.searchBar = components.NewSimpleSearchBar("🐙 ", 0)
+	s.searchHint = tview.NewTextView().SetDynamicColors(true)
+	s.searchRow = tview.NewFlex().
+		AddItem(s.searchBar.InputField, 0, 1, true).
+		AddItem(s.searchHint, 0, 1, false)
 	s.initLiveSearch()
+	s.initSearchCompletion()
+	s.searchBar.Mode = s.searchMode
+	s.searchBar.EnableModes(func(mode components.SearchMode) {
+		s.searchMode = mode
+		s.setSearchQuery(s.searchBar.InputField.GetText())
+	})
 	s.searchBar.InputField.SetDoneFunc(s.searchDone)
 
 	s.table = s.tableView.Init()
-	s.tableView.FillTable(s.filteredApps, s.getActiveFiltersText())
+	s.tableView.FillTable(s.filteredApps, s.getActiveFiltersText(), nil)
 
 	s.grid = tview.NewGrid().
 		SetRows(4, 0, 1). // header (topbar), table, footer
@@ -128,26 +160,124 @@ func (s *ScreenAppList) Init() tview.Primitive {
 	return s.pages
 }
 
-func (s *ScreenAppList) startAutoRefresh() {
+// startLiveUpdates subscribes to the application Watch stream. Init runs on
+// every screen switch, so the subscription is only started once.
+func (s *ScreenAppList) startLiveUpdates() {
+	if s.watchCancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.watchCancel = cancel
+
+	go s.client.WatchApps(ctx,
+		func(ev argocd.AppEvent) {
+			s.app.QueueUpdateDraw(func() {
+				s.applyAppEvent(ev)
+			})
+		},
+		func(connected bool, err error) {
+			s.app.QueueUpdateDraw(func() {
+				s.onStreamStatus(connected)
+			})
+		},
+	)
+
 	go func() {
-		ticker := time.NewTicker(60 * time.Second)
+		ticker := time.NewTicker(pollInterval)
 		defer ticker.Stop()
 		for {
-			<-ticker.C
-			s.app.QueueUpdateDraw(func() {
-				s.refreshApps()
-			})
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			var connected bool
+			s.app.QueueUpdate(func() { connected = s.streamConnected })
+			if connected {
+				continue
+			}
+			s.fetchApps()
 		}
 	}()
 }
 
+func (s *ScreenAppList) stopLiveUpdates() {
+	if s.watchCancel != nil {
+		s.watchCancel()
+		s.watchCancel = nil
+	}
+}
+
+func (s *ScreenAppList) onStreamStatus(connected bool) {
+	if connected && s.streamDropped {
+		// Deletions that happened while disconnected are not replayed by the stream
+		go s.fetchApps()
+		s.streamDropped = false
+	}
+	if !connected {
+		s.streamDropped = true
+	}
+	s.streamConnected = connected
+	if s.footer != nil {
+		s.footer.SetLive(connected)
+	}
+}
+
+func (s *ScreenAppList) applyAppEvent(ev argocd.AppEvent) {
+	idx := -1
+	for i := range s.apps {
+		if s.apps[i].Name == ev.App.Name {
+			idx = i
+			break
+		}
+	}
+
+	switch ev.Type {
+	case argocd.EventDeleted:
+		if idx < 0 {
+			return
+		}
+		s.apps = append(s.apps[:idx:idx], s.apps[idx+1:]...)
+	default:
+		if idx >= 0 {
+			s.apps[idx] = ev.App
+		} else {
+			s.apps = append(s.apps, ev.App)
+		}
+	}
+
+	s.lastRefreshTime = time.Now()
+	s.onAppsChanged()
+}
+
 func (s *ScreenAppList) refreshApps() {
 	newApps, err := s.client.GetApps()
 	if err != nil {
 		return
 	}
+	s.setApps(newApps)
+}
+
+// fetchApps is refreshApps for the background updates: the request runs on
+// the calling goroutine and only the result is applied on the UI goroutine.
+func (s *ScreenAppList) fetchApps() {
+	apps, err := s.client.GetApps()
+	if err != nil {
+		return
+	}
+	s.app.QueueUpdateDraw(func() { s.setApps(apps) })
+}
+
+func (s *ScreenAppList) setApps(apps []argocd.Application) {
 	s.lastRefreshTime = time.Now()
-	s.apps = newApps
+	s.apps = apps
+	s.onAppsChanged()
+}
+
+func (s *ScreenAppList) onAppsChanged() {
+	if s.tableView == nil {
+		return
+	}
 	s.applyFilters()
 
 	healthy, degraded, outOfSync := s.getApplicationStats()
@@ -188,12 +318,13 @@ func (s *ScreenAppList) applyFilters() {
 		filteredApps = filtered
 	}
 
-	if s.searchQuery != "" {
-		filteredApps = filterApplications(filteredApps, s.searchQuery)
+	s.searchMatches = nil
+	if s.query != nil {
+		filteredApps, s.searchMatches = filterApplications(filteredApps, s.query, s.searchMode)
 	}
 
 	s.filteredApps = filteredApps
-	s.tableView.FillTable(s.filteredApps, s.getActiveFiltersText())
+	s.tableView.FillTable(s.filteredApps, s.getActiveFiltersText(), s.searchMatches)
 }
 
 func (s *ScreenAppList) getActiveFiltersText() string {
@@ -226,48 +357,83 @@ func (s *ScreenAppList) initLiveSearch() {
 		}
 		debounceTimer = time.AfterFunc(500*time.Millisecond, func() {
 			s.app.QueueUpdateDraw(func() {
-				s.searchQuery = text
-				s.applyFilters()
+				s.setSearchQuery(text)
 			})
 		})
 	})
 }
 
+// setSearchQuery parses text and filters by it. A query that does not parse
+// leaves the previous filter in place and shows the error next to the input.
+func (s *ScreenAppList) setSearchQuery(text string) bool {
+	query, err := parseQuery(text, s.searchMode)
+	if err != nil {
+		s.searchHint.SetText("[red]" + tview.Escape(err.Error()))
+		return false
+	}
+	s.searchQuery = text
+	s.query = query
+	s.searchHint.SetText(searchSyntaxHint)
+	s.applyFilters()
+	return true
+}
+
+const searchSyntaxHint = "[gray]field:value, !=, ~regex, age>7d, AND/OR/NOT, ( ), Ctrl+T mode"
+
+// initSearchCompletion completes field names and the values of the loaded
+// applications for the word under the cursor.
+func (s *ScreenAppList) initSearchCompletion() {
+	input := s.searchBar.InputField
+	input.SetAutocompleteStyles(
+		tcell.NewHexColor(0x1a1a1a),
+		tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.NewHexColor(0x1a1a1a)),
+		tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.NewHexColor(0x017be9)),
+	)
+	input.SetAutocompleteFunc(func(text string) []string {
+		return completeQuery(text, s.apps)
+	})
+	input.SetAutocompletedFunc(func(text string, _ int, source int) bool {
+		if source == tview.AutocompletedNavigate {
+			return false
+		}
+		input.SetText(text)
+		// Keep the list open to offer the values of a completed field name
+		return !strings.HasSuffix(text, ":")
+	})
+}
+
+// showSearchBar opens the search row as an overlay, so that the global key
+// bindings leave 'q' and Esc to the input.
 func (s *ScreenAppList) showSearchBar() {
-	s.searchBar.InputField.SetText("")
+	s.searchBar.InputField.SetText(s.searchQuery)
+	s.searchHint.SetText(searchSyntaxHint)
 	s.grid.RemoveItem(s.table)
 	s.grid.SetRows(4, 1, -1, 1) // topBar, searchBar, table, footer
-	s.grid.AddItem(s.searchBar.InputField, 1, 0, 1, 1, 0, 0, false)
+	s.grid.AddItem(s.searchRow, 1, 0, 1, 1, 0, 0, false)
 	s.grid.AddItem(s.table, 2, 0, 1, 1, 0, 0, true)
+	s.router.ShowOverlay(s.pages)
 	s.app.SetFocus(s.searchBar.InputField)
 }
 
 func (s *ScreenAppList) hideSearchBar() {
-	s.grid.RemoveItem(s.searchBar.InputField)
+	s.grid.RemoveItem(s.searchRow)
 	s.grid.RemoveItem(s.table)
 	s.grid.SetRows(4, -1, 1) // topBar, table, footer
 	s.grid.AddItem(s.table, 1, 0, 1, 1, 0, 0, true)
+	s.router.CloseOverlay(s.pages)
 	s.app.SetFocus(s.table)
 }
 
 func (s *ScreenAppList) searchDone(key tcell.Key) {
-	if key == tcell.KeyEnter {
-		s.searchQuery = s.searchBar.InputField.GetText()
-		s.applyFilters()
-		s.hideSearchBar()
-		s.app.SetFocus(s.table)
-	}
-}
-
-func filterApplications(apps []argocd.Application, query string) []argocd.Application {
-	filtered := make([]argocd.Application, 0, len(apps))
-	lowerQuery := strings.ToLower(query)
-	for _, app := range apps {
-		if strings.Contains(app.SearchString(), lowerQuery) {
-			filtered = append(filtered, app)
+	switch key {
+	case tcell.KeyEnter:
+		if !s.setSearchQuery(s.searchBar.InputField.GetText()) {
+			return
 		}
+		s.hideSearchBar()
+	case tcell.KeyEscape:
+		s.hideSearchBar()
 	}
-	return filtered
 }
 
 func (s *ScreenAppList) onGridKey(event *tcell.EventKey) *tcell.EventKey {
@@ -276,6 +442,7 @@ func (s *ScreenAppList) onGridKey(event *tcell.EventKey) *tcell.EventKey {
 	}
 	switch event.Rune() {
 	case 'I':
+		s.stopLiveUpdates()
 		s.router.SwitchTo("InstanceSelection")
 		return nil
 	case '?':
@@ -286,11 +453,11 @@ func (s *ScreenAppList) onGridKey(event *tcell.EventKey) *tcell.EventKey {
 		if s.footer != nil {
 			s.footer.Stop()
 		}
+		s.stopLiveUpdates()
 		s.app.Stop()
 		return nil
 	case '/', ':':
 		s.showSearchBar()
-		s.app.SetFocus(s.searchBar.InputField)
 		return nil
 	case 'R':
 		s.refreshApps()
@@ -319,15 +486,60 @@ func (s *ScreenAppList) onGridKey(event *tcell.EventKey) *tcell.EventKey {
 			return event
 		}
 		selectedApp := s.filteredApps[row-1]
-		err := s.syncApplication(selectedApp.Name)
-		if err != nil {
-			modal := components.ErrorModal(
-				fmt.Sprintf("Error syncing app %s:", selectedApp.Name),
-				err.Error(),
-				s.modalClose,
-			)
-			s.app.SetRoot(modal, true)
+		s.showSyncOptions(selectedApp.Name)
+		return nil
+	case 'i':
+		row, _ := s.table.GetSelection()
+		if row < 1 || row-1 >= len(s.filteredApps) {
+			return event
+		}
+		selectedApp := s.filteredApps[row-1]
+		detailsScreen := applicationDetails.New(s.app, s.router, s.instanceInfo, s.client, selectedApp.Name)
+		s.router.ReplaceScreen(detailsScreen)
+		s.router.SwitchTo(detailsScreen.Name())
+		return nil
+	case 'w':
+		row, _ := s.table.GetSelection()
+		if row < 1 || row-1 >= len(s.filteredApps) {
+			return event
 		}
+		selectedApp := s.filteredApps[row-1]
+		operationScreen := operationProgress.New(s.app, s.router, s.instanceInfo, s.client, selectedApp.Name)
+		s.router.ReplaceScreen(operationScreen)
+		s.router.SwitchTo(operationScreen.Name())
+		return nil
+	case 'A':
+		row, _ := s.table.GetSelection()
+		if row < 1 || row-1 >= len(s.filteredApps) {
+			return event
+		}
+		selectedApp := s.filteredApps[row-1]
+		s.showSyncPolicy(selectedApp)
+		return nil
+	case 'e':
+		row, _ := s.table.GetSelection()
+		if row < 1 || row-1 >= len(s.filteredApps) {
+			return event
+		}
+		selectedApp := s.filteredApps[row-1]
+		s.editSpec(selectedApp.Name)
+		return nil
+	case 'm':
+		row, _ := s.table.GetSelection()
+		if row < 1 || row-1 >= len(s.filteredApps) {
+			return event
+		}
+		selectedApp := s.filteredApps[row-1]
+		parametersScreen := applicationParameters.New(s.app, s.router, s.instanceInfo, s.client, selectedApp.Name)
+		s.router.ReplaceScreen(parametersScreen)
+		s.router.SwitchTo(parametersScreen.Name())
+		return nil
+	case 'n':
+		createScreen := createApplication.New(s.app, s.instanceInfo, s.client, func() {
+			s.router.CloseOverlay(s.pages)
+			s.app.SetFocus(s.table)
+		})
+		s.router.ShowOverlay(createScreen.Init())
 		return nil
 	case 'D':
 		row, _ := s.table.GetSelection()
@@ -385,6 +597,7 @@ func (s *ScreenAppList) onGridKey(event *tcell.EventKey) *tcell.EventKey {
 		s.healthFilter = ""
 		s.syncFilter = ""
 		s.searchQuery = ""
+		s.query = nil
 		s.applyFilters()
 		return nil
 	}
@@ -406,15 +619,30 @@ func (s *ScreenAppList) onGridKey(event *tcell.EventKey) *tcell.EventKey {
 			s.instanceInfo,
 			s.client,
 		)
-		s.router.AddScreen(resScreen)
+		s.router.ReplaceScreen(resScreen)
 		s.router.SwitchTo(resScreen.Name())
 		return nil
 	}
 	return event
 }
 
-func (s *ScreenAppList) syncApplication(appName string) error {
-	err := s.client.SyncApp(appName)
+func (s *ScreenAppList) showSyncOptions(appName string) {
+	opts := components.LastSyncOptions(s.instanceInfo.Name)
+	dialog := components.SyncOptionsModal(appName, opts,
+		func(opts argocd.SyncOptions) {
+			components.RememberSyncOptions(s.instanceInfo.Name, opts)
+			s.router.CloseOverlay(s.pages)
+			s.syncApplication(appName, opts)
+		},
+		func() {
+			s.router.CloseOverlay(s.pages)
+		},
+	)
+	s.router.ShowOverlay(dialog)
+}
+
+func (s *ScreenAppList) syncApplication(appName string, opts argocd.SyncOptions) error {
+	err := s.client.SyncApp(appName, opts)
 	if err != nil {
 		modal := components.ErrorModal(
 			fmt.Sprintf("Error syncing app %s:", appName),
@@ -424,37 +652,181 @@ func (s *ScreenAppList) syncApplication(appName string) error {
 		s.app.SetRoot(modal, true)
 		return err
 	}
-	s.showToast(fmt.Sprintf("App %s synced successfully!", appName), 2*time.Second)
+	if opts.DryRun {
+		s.showToast(fmt.Sprintf("Dry run of %s completed", appName), 2*time.Second)
+	} else {
+		s.showToast(fmt.Sprintf("App %s synced successfully!", appName), 2*time.Second)
+	}
 	s.refreshApps()
 	return nil
 }
 
-func (s *ScreenAppList) confirmAndDeleteApplication(appName string) {
+func (s *ScreenAppList) showSyncPolicy(app argocd.Application) {
+	dialog := components.SyncPolicyModal(app.Name, app.SyncPolicy,
+		func(policy argocd.SyncPolicy) {
+			s.router.CloseOverlay(s.pages)
+			s.reviewSyncPolicy(app.Name, policy)
+		},
+		func() {
+			s.router.CloseOverlay(s.pages)
+		},
+	)
+	s.router.ShowOverlay(dialog)
+}
+
+// reviewSyncPolicy shows how the spec changes with the new policy and
+// applies it once confirmed.
+func (s *ScreenAppList) reviewSyncPolicy(appName string, policy argocd.SyncPolicy) {
+	showError := func(err error) {
+		modal := components.ErrorModal(
+			fmt.Sprintf("Error updating sync policy of %s:", appName),
+			err.Error(),
+			s.modalClose,
+		)
+		s.app.SetRoot(modal, true)
+	}
+
+	spec, err := s.client.GetAppSpec(appName)
+	if err != nil {
+		showError(err)
+		return
+	}
+	updated, err := argocd.SpecWithSyncPolicy(spec.YAML, policy)
+	if err != nil {
+		showError(err)
+		return
+	}
+	unified := diff.Unified("spec", "spec", spec.YAML, updated, 3)
+	if unified == "" {
+		s.showToast(fmt.Sprintf("Sync policy of %s is unchanged", appName), 2*time.Second)
+		return
+	}
+
+	dialog := components.DiffConfirmModal(fmt.Sprintf("Update sync policy of %s?", appName), unified,
+		func() {
+			s.router.CloseOverlay(s.pages)
+			if err := s.client.SetSyncPolicy(appName, policy); err != nil {
+				showError(err)
+				return
+			}
+			s.showToast(fmt.Sprintf("Sync policy of %s updated", appName), 2*time.Second)
+		},
+		func() {
+			s.router.CloseOverlay(s.pages)
+		},
+	)
+	s.router.ShowOverlay(dialog)
+}
+
+// editSpec opens the spec of the application in the external editor.
+func (s *ScreenAppList) editSpec(appName string) {
+	spec, err := s.client.GetAppSpec(appName)
+	if err != nil {
+		modal := components.ErrorModal(
+			fmt.Sprintf("Error loading spec of %s:", appName),
+			err.Error(),
+			s.modalClose,
+		)
+		s.app.SetRoot(modal, true)
+		return
+	}
+	s.editSpecText(appName, spec, spec.YAML)
+}
+
+// editSpecText lets the user edit text, validates the result and applies it
+// on top of base after showing the diff. Every failure offers to edit again
+// so no changes are lost.
+func (s *ScreenAppList) editSpecText(appName string, base argocd.AppSpec, text string) {
+	edited, err := common.EditInEditor(s.app, appName+"-*.yaml", text)
+	if err != nil {
+		modal := components.ErrorModal(
+			fmt.Sprintf("Error running editor for %s:", appName),
+			err.Error(),
+			s.modalClose,
+		)
+		s.app.SetRoot(modal, true)
+		return
+	}
+	reEdit := func() { s.editSpecText(appName, base, edited) }
+
+	if err := argocd.ValidateAppSpec(edited); err != nil {
+		s.offerReEdit(fmt.Sprintf("The edited spec of %s is invalid:\n\n%s", appName, err), reEdit)
+		return
+	}
+	unified := diff.Unified("spec", "spec", base.YAML, edited, 3)
+	if unified == "" {
+		s.showToast(fmt.Sprintf("Spec of %s is unchanged", appName), 2*time.Second)
+		return
+	}
+
+	dialog := components.DiffConfirmModal(fmt.Sprintf("Update spec of %s?", appName), unified,
+		func() {
+			s.router.CloseOverlay(s.pages)
+			err := s.client.UpdateAppSpec(appName, argocd.AppSpec{YAML: edited, ResourceVersion: base.ResourceVersion})
+			switch {
+			case errors.Is(err, argocd.ErrConflict):
+				// Rebase the edits on the current spec; the next diff shows
+				// what changed on the server as well
+				s.offerReEdit(fmt.Sprintf("%s was modified on the server. Edit again on top of the current spec?", appName),
+					func() {
+						latest, err := s.client.GetAppSpec(appName)
+						if err != nil {
+							s.offerReEdit(err.Error(), reEdit)
+							return
+						}
+						s.editSpecText(appName, latest, edited)
+					})
+			case err != nil:
+				s.offerReEdit(fmt.Sprintf("Error updating %s:\n\n%s", appName, err), reEdit)
+			default:
+				s.showToast(fmt.Sprintf("Spec of %s updated", appName), 2*time.Second)
+			}
+		},
+		func() {
+			s.router.CloseOverlay(s.pages)
+		},
+	)
+	s.router.ShowOverlay(dialog)
+}
+
+func (s *ScreenAppList) offerReEdit(message string, reEdit func()) {
 	modal := tview.NewModal().
-		SetText(fmt.Sprintf("Are you sure you want to delete application %s?", appName)).
-		AddButtons([]string{"Yes", "No"}).
+		SetText(message).
+		AddButtons([]string{"Edit again", "Discard"}).
 		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-			if buttonIndex == 0 { // "Yes" button
-				err := s.client.DeleteApp(appName)
-				if err != nil {
-					errorModal := components.ErrorModal(
-						fmt.Sprintf("Error deleting app %s:", appName),
-						err.Error(),
-						s.modalClose,
-					)
-					s.app.SetRoot(errorModal, true)
-					return
-				}
-				s.showToast(fmt.Sprintf("App %s deleted successfully!", appName), 2*time.Second)
-				s.refreshApps()
-			}
 			s.app.SetRoot(s.pages, true)
+			if buttonIndex == 0 {
+				reEdit()
+			}
 		})
-
-	modal.SetBackgroundColor(tcell.ColorDarkRed)
+	modal.SetBackgroundColor(tcell.ColorMidnightBlue).SetTextColor(tcell.ColorWhite)
 	s.app.SetRoot(modal, true)
 }
 
+func (s *ScreenAppList) confirmAndDeleteApplication(appName string) {
+	confirmName := s.instanceInfo.Production
+	dialog := components.DeleteAppModal(appName, confirmName,
+		func(opts argocd.DeleteOptions) {
+			s.router.CloseOverlay(s.pages)
+			if err := s.client.DeleteApp(appName, opts); err != nil {
+				errorModal := components.ErrorModal(
+					fmt.Sprintf("Error deleting app %s:", appName),
+					err.Error(),
+					s.modalClose,
+				)
+				s.app.SetRoot(errorModal, true)
+				return
+			}
+			s.showToast(fmt.Sprintf("App %s deleted successfully!", appName), 2*time.Second)
+			s.refreshApps()
+		},
+		func() {
+			s.router.CloseOverlay(s.pages)
+		},
+	)
+	s.router.ShowOverlay(dialog)
+}
+
 func (s *ScreenAppList) modalClose() {
 	s.app.SetRoot(s.pages, true)
 }
@@ -0,0 +1,173 @@
+package applicationlist
+
+import (
+	"testing"
+
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd"
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd/fake"
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd/uitest"
+	"github.com/Jack200062/ArguTUI/internal/ui"
+	"github.com/Jack200062/ArguTUI/internal/ui/common"
+	"github.com/Jack200062/ArguTUI/internal/ui/components"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func newAppListScreen(t *testing.T, b *fake.Backend) (*tview.Application, *ui.Router, *ScreenAppList) {
+	t.Helper()
+	app, router := uitest.Start(t)
+	apps, err := b.GetApps()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := New(app, b, router, common.NewInstanceInfo("https://argocd.example.com", "test"), apps)
+	router.ReplaceScreen(s)
+	if err := router.SwitchTo(s.Name()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(s.stopLiveUpdates)
+	uitest.WaitForCall(t, b, "WatchApps")
+	return app, router, s
+}
+
+func appNames(apps []argocd.Application) []string {
+	names := make([]string, len(apps))
+	for i, app := range apps {
+		names[i] = app.Name
+	}
+	return names
+}
+
+func TestWatchEventsUpdateTheList(t *testing.T) {
+	b := fake.New(
+		argocd.Application{Name: "api", Project: "payments", HealthStatus: "Healthy", SyncStatus: "Synced"},
+		argocd.Application{Name: "web", Project: "frontend", HealthStatus: "Healthy", SyncStatus: "Synced"},
+	)
+	app, _, s := newAppListScreen(t, b)
+
+	b.Script(
+		fake.Transition{Type: argocd.EventModified, App: argocd.Application{
+			Name: "api", Project: "payments", HealthStatus: "Degraded", SyncStatus: "OutOfSync",
+		}},
+		fake.Transition{Type: argocd.EventDeleted, App: argocd.Application{Name: "web"}},
+	)
+	b.Step()
+	b.Step()
+
+	uitest.Eventually(t, app, func() bool {
+		return len(s.filteredApps) == 1 && s.filteredApps[0].HealthStatus == "Degraded"
+	})
+}
+
+func TestSearchQueryFiltersTheList(t *testing.T) {
+	b := fake.New(
+		argocd.Application{Name: "api", Project: "payments", HealthStatus: "Degraded", SyncStatus: "OutOfSync"},
+		argocd.Application{Name: "api-docs", Project: "payments", HealthStatus: "Healthy", SyncStatus: "Synced"},
+		argocd.Application{Name: "web", Project: "frontend", HealthStatus: "Degraded", SyncStatus: "OutOfSync"},
+	)
+	app, _, s := newAppListScreen(t, b)
+	uitest.Eventually(t, app, func() bool { return len(s.apps) == 3 })
+
+	uitest.OnUI(app, func() {
+		if !s.setSearchQuery("project:payments health:degraded") {
+			t.Error("query did not parse")
+		}
+	})
+	uitest.OnUI(app, func() {
+		if got := appNames(s.filteredApps); len(got) != 1 || got[0] != "api" {
+			t.Errorf("filtered apps = %v, want [api]", got)
+		}
+		// An invalid query keeps the previous filter
+		if s.setSearchQuery("project:") {
+			t.Error("incomplete query parsed")
+		}
+		if len(s.filteredApps) != 1 {
+			t.Errorf("filtered apps = %v after an invalid query", appNames(s.filteredApps))
+		}
+	})
+}
+
+func TestEnterOpensTheResourcesOfTheSelectedApp(t *testing.T) {
+	b := fake.New(
+		argocd.Application{Name: "api", Project: "payments", HealthStatus: "Healthy", SyncStatus: "Synced"},
+		argocd.Application{Name: "web", Project: "frontend", HealthStatus: "Healthy", SyncStatus: "Synced"},
+	)
+	app, router, s := newAppListScreen(t, b)
+	uitest.Eventually(t, app, func() bool { return len(s.filteredApps) == 2 })
+
+	open := func(row int) {
+		uitest.OnUI(app, func() { s.table.Select(row, 0) })
+		s.onGridKey(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+	}
+	lastTreeRequest := func() string {
+		var appName string
+		for _, call := range b.Calls() {
+			if call.Method == "GetResourceTree" {
+				appName = call.Args[0]
+			}
+		}
+		return appName
+	}
+
+	open(1)
+	if got := lastTreeRequest(); got != "api" {
+		t.Fatalf("resource tree loaded for %q, want api", got)
+	}
+	if err := router.Back(); err != nil {
+		t.Fatal(err)
+	}
+	open(2)
+	if got := lastTreeRequest(); got != "web" {
+		t.Fatalf("resource tree loaded for %q, want web", got)
+	}
+}
+
+func TestDeleteRemovesTheApp(t *testing.T) {
+	b := fake.New(
+		argocd.Application{Name: "api", Project: "payments", HealthStatus: "Healthy", SyncStatus: "Synced"},
+		argocd.Application{Name: "web", Project: "frontend", HealthStatus: "Healthy", SyncStatus: "Synced"},
+	)
+	app, _, s := newAppListScreen(t, b)
+	uitest.Eventually(t, app, func() bool { return len(s.filteredApps) == 2 })
+
+	if err := b.DeleteApp("web", argocd.DeleteOptions{Cascade: true, PropagationPolicy: argocd.PropagationForeground}); err != nil {
+		t.Fatal(err)
+	}
+	uitest.Eventually(t, app, func() bool {
+		names := appNames(s.filteredApps)
+		return len(names) == 1 && names[0] == "api"
+	})
+}
+
+func TestSearchRowKeepsQAndClosesOnEscape(t *testing.T) {
+	b := fake.New(argocd.Application{Name: "api", Project: "payments", HealthStatus: "Healthy", SyncStatus: "Synced"})
+	app, router, s := newAppListScreen(t, b)
+
+	uitest.OnUI(app, func() {
+		s.onGridKey(tcell.NewEventKey(tcell.KeyRune, '/', tcell.ModNone))
+		// The global bindings would otherwise take 'q' from the input
+		if !router.IsModalActive() {
+			t.Error("search row is not shown as an overlay")
+		}
+		s.searchDone(tcell.KeyEscape)
+		if router.IsModalActive() {
+			t.Error("overlay still active after Esc")
+		}
+		if app.GetFocus() != s.table {
+			t.Error("table is not focused after Esc")
+		}
+	})
+}
+
+func TestSearchModeSurvivesInit(t *testing.T) {
+	b := fake.New(argocd.Application{Name: "api", Project: "payments", HealthStatus: "Healthy", SyncStatus: "Synced"})
+	app, _, s := newAppListScreen(t, b)
+
+	uitest.OnUI(app, func() {
+		s.searchBar.InputField.GetInputCapture()(tcell.NewEventKey(tcell.KeyCtrlT, 0, tcell.ModNone))
+		s.Init()
+		if s.searchBar.Mode != components.SearchSubstring {
+			t.Errorf("search mode after Init = %s, want substring", s.searchBar.Mode)
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/Jack200062/ArguTUI/internal/transport/argocd"
 	"github.com/Jack200062/ArguTUI/internal/ui/common"
+	"github.com/Jack200062/ArguTUI/internal/ui/components"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
@@ -43,7 +44,9 @@ func (t *TableView) Init() *tview.Table {
 	return t.table
 }
 
-func (t *TableView) FillTable(apps []argocd.Application, activeFilters string) {
+// FillTable shows apps, highlighting the offsets in matches, by app name,
+// where the search matched their SearchString.
+func (t *TableView) FillTable(apps []argocd.Application, activeFilters string, matches map[string][]int) {
 	t.table.Clear()
 	headers := []string{"Name", "HealthStatus", "SyncStatus", "SyncCommit", "Project", "LastActivity"}
 	for col, h := range headers {
@@ -62,11 +65,14 @@ func (t *TableView) FillTable(apps []argocd.Application, activeFilters string) {
 
 	row := 1
 	for _, app := range apps {
-		nameCell := tview.NewTableCell(app.Name).SetExpansion(1)
-		healthStatusCell := tview.NewTableCell(app.HealthStatus).SetExpansion(1)
-		syncStatusCell := tview.NewTableCell(app.SyncStatus).SetExpansion(1)
-		syncCommitCell := tview.NewTableCell(app.SyncCommit).SetExpansion(1)
-		projectCell := tview.NewTableCell(app.Project).SetExpansion(1)
+		// The fields in the order they are joined by SearchString
+		split := components.SplitMatches(matches[app.Name],
+			app.Name, app.HealthStatus, app.Project, app.SyncStatus, app.SyncCommit)
+		nameCell := tview.NewTableCell(components.Highlight(app.Name, split[0])).SetExpansion(1)
+		healthStatusCell := tview.NewTableCell(components.Highlight(app.HealthStatus, split[1])).SetExpansion(1)
+		syncStatusCell := tview.NewTableCell(components.Highlight(app.SyncStatus, split[3])).SetExpansion(1)
+		syncCommitCell := tview.NewTableCell(components.Highlight(app.SyncCommit, split[4])).SetExpansion(1)
+		projectCell := tview.NewTableCell(components.Highlight(app.Project, split[2])).SetExpansion(1)
 		lastActivityCell := tview.NewTableCell(app.LastActivity).SetExpansion(1)
 
 		t.table.SetCell(row, 0, nameCell)
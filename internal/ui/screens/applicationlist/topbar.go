@@ -49,6 +49,12 @@ func (t *TopBar) Init() tview.Primitive {
 		"f":     "Filter",
 		"h/d/p": "Sort By Health",
 		"s/o":   "Sort By Sync",
+		"i":     "Details",
+		"w":     "Operation",
+		"A":     "Auto-sync Policy",
+		"e":     "Edit Spec",
+		"m":     "Parameters",
+		"n":     "New App",
 	})
 
 	shortcutBar.AddGroup("Actions", map[string]string{
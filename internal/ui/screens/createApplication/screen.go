@@ -0,0 +1,486 @@
+package createApplication
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd"
+	"github.com/Jack200062/ArguTUI/internal/ui/common"
+	"github.com/Jack200062/ArguTUI/internal/ui/components"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	stepGeneral = iota
+	stepSource
+	stepDestination
+	stepSync
+	stepPreview
+)
+
+var stepTitles = []string{"General", "Source", "Destination", "Sync policy", "Preview"}
+
+// syncOptions offered by the wizard; others can be added later by editing the spec.
+var syncOptions = []string{
+	"CreateNamespace=true",
+	"PruneLast=true",
+	"ServerSideApply=true",
+	"ApplyOutOfSyncOnly=true",
+}
+
+// ScreenCreateApp is a wizard creating an application step by step. Input
+// is checked against the projects, clusters and repositories known to
+// ArgoCD before the manifest is previewed and submitted.
+//
+// The wizard is shown as an overlay of the screen opening it, so that the
+// global key bindings do not take 'q' and Esc from its fields.
+type ScreenCreateApp struct {
+	app          *tview.Application
+	instanceInfo *common.InstanceInfo
+	client       argocd.Backend
+	// onClose restores the screen below once the wizard is done
+	onClose func()
+
+	pages      *tview.Pages
+	content    *tview.Flex
+	stepView   *tview.TextView
+	statusView *tview.TextView
+
+	step     int
+	draft    argocd.AppDraft
+	manifest string
+
+	loaded bool
+	// nil lists could not be loaded; their checks are skipped
+	projects []argocd.Project
+	clusters []argocd.Cluster
+	repos    []argocd.Repository
+}
+
+func New(
+	app *tview.Application,
+	instanceInfo *common.InstanceInfo,
+	client argocd.Backend,
+	onClose func(),
+) *ScreenCreateApp {
+	return &ScreenCreateApp{
+		app:          app,
+		instanceInfo: instanceInfo,
+		client:       client,
+		onClose:      onClose,
+		draft: argocd.AppDraft{
+			Project:        "default",
+			TargetRevision: "HEAD",
+		},
+	}
+}
+
+func (s *ScreenCreateApp) Init() tview.Primitive {
+	textColor := tcell.NewHexColor(0x00bebe)
+	backgroundColor := tcell.NewHexColor(0x000000)
+	borderColor := tcell.NewHexColor(0x63a0bf)
+	shortcutKeyColor := tcell.NewHexColor(0x017be9)
+
+	instanceView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(s.instanceInfo.FormattedString(tcell.ColorYellow)).
+		SetTextAlign(tview.AlignLeft)
+	instanceView.SetBackgroundColor(backgroundColor)
+
+	shortcutBar := components.NewShortcutBar(backgroundColor, shortcutKeyColor)
+	shortcutBar.AddGroup("Wizard", map[string]string{
+		"Tab":   "Next field",
+		"Enter": "Select",
+		"Esc":   "Cancel",
+	})
+
+	topBar := tview.NewFlex().
+		SetDirection(tview.FlexColumn).
+		AddItem(instanceView, 0, 1, false).
+		AddItem(shortcutBar.Init(), 0, 2, false)
+	topBar.SetBackgroundColor(backgroundColor)
+
+	s.stepView = tview.NewTextView().SetDynamicColors(true)
+	s.stepView.SetBackgroundColor(backgroundColor)
+
+	s.content = tview.NewFlex().SetDirection(tview.FlexRow)
+	s.content.SetBackgroundColor(backgroundColor)
+	s.content.SetBorder(true).
+		SetTitle(" New application ").
+		SetTitleAlign(tview.AlignCenter).
+		SetTitleColor(textColor).
+		SetBorderColor(borderColor)
+
+	s.statusView = tview.NewTextView().SetDynamicColors(true)
+	s.statusView.SetBackgroundColor(backgroundColor)
+
+	grid := tview.NewGrid().
+		SetRows(3, 1, 0, 1).
+		SetColumns(0).
+		SetBorders(true)
+	grid.AddItem(topBar, 0, 0, 1, 1, 0, 0, false).
+		AddItem(s.stepView, 1, 0, 1, 1, 0, 0, false).
+		AddItem(s.content, 2, 0, 1, 1, 0, 0, true).
+		AddItem(s.statusView, 3, 0, 1, 1, 0, 0, false)
+
+	s.pages = tview.NewPages().
+		AddPage("main", grid, true, true)
+
+	if !s.loaded {
+		s.loadCatalog()
+	}
+	s.showStep()
+	return s.pages
+}
+
+// loadCatalog fetches what the input is validated against.
+func (s *ScreenCreateApp) loadCatalog() {
+	s.loaded = true
+	var failed []string
+	if projects, err := s.client.ListProjects(); err == nil {
+		sort.Slice(projects, func(i, j int) bool { return projects[i].Name < projects[j].Name })
+		s.projects = projects
+	} else {
+		failed = append(failed, "projects")
+	}
+	if clusters, err := s.client.ListClusters(); err == nil {
+		s.clusters = clusters
+	} else {
+		failed = append(failed, "clusters")
+	}
+	if repos, err := s.client.ListRepositories(); err == nil {
+		s.repos = repos
+	} else {
+		failed = append(failed, "repositories")
+	}
+	if len(failed) > 0 {
+		s.statusView.SetText(fmt.Sprintf("[orange]Could not load %s, they will not be validated[-]",
+			strings.Join(failed, ", ")))
+	}
+}
+
+func (s *ScreenCreateApp) showStep() {
+	var parts []string
+	for i, title := range stepTitles {
+		if i == s.step {
+			parts = append(parts, fmt.Sprintf("[yellow::b]%d. %s[-:-:-]", i+1, title))
+		} else {
+			parts = append(parts, fmt.Sprintf("[gray]%d. %s[-]", i+1, title))
+		}
+	}
+	s.stepView.SetText(strings.Join(parts, "  >  "))
+
+	s.content.Clear()
+	if s.step == stepPreview {
+		s.showPreview()
+		return
+	}
+
+	form := s.newForm()
+	switch s.step {
+	case stepGeneral:
+		form.AddInputField("Name", s.draft.Name, 50, nil, func(text string) {
+			s.draft.Name = strings.TrimSpace(text)
+		})
+		if s.projects != nil {
+			names := make([]string, len(s.projects))
+			current := 0
+			for i, p := range s.projects {
+				names[i] = p.Name
+				if p.Name == s.draft.Project {
+					current = i
+				}
+			}
+			if len(names) > 0 {
+				s.draft.Project = names[current]
+			}
+			form.AddDropDown("Project", names, current, func(option string, index int) {
+				s.draft.Project = option
+			})
+		} else {
+			form.AddInputField("Project", s.draft.Project, 50, nil, func(text string) {
+				s.draft.Project = strings.TrimSpace(text)
+			})
+		}
+	case stepSource:
+		repoField := tview.NewInputField().
+			SetLabel("Repository URL").
+			SetText(s.draft.RepoURL).
+			SetFieldWidth(60).
+			SetChangedFunc(func(text string) {
+				s.draft.RepoURL = strings.TrimSpace(text)
+			})
+		repoField.SetAutocompleteFunc(s.completeRepo)
+		form.AddFormItem(repoField)
+		form.AddInputField("Path (git)", s.draft.Path, 50, nil, func(text string) {
+			s.draft.Path = strings.TrimSpace(text)
+		})
+		form.AddInputField("Chart (helm)", s.draft.Chart, 50, nil, func(text string) {
+			s.draft.Chart = strings.TrimSpace(text)
+		})
+		form.AddInputField("Target revision", s.draft.TargetRevision, 30, nil, func(text string) {
+			s.draft.TargetRevision = strings.TrimSpace(text)
+		})
+	case stepDestination:
+		if len(s.clusters) > 0 {
+			options := make([]string, len(s.clusters))
+			current := 0
+			for i, c := range s.clusters {
+				options[i] = fmt.Sprintf("%s (%s)", c.Name, c.Server)
+				if c.Server == s.draft.DestServer {
+					current = i
+				}
+			}
+			s.draft.DestServer = s.clusters[current].Server
+			form.AddDropDown("Cluster", options, current, func(option string, index int) {
+				if index >= 0 {
+					s.draft.DestServer = s.clusters[index].Server
+				}
+			})
+		} else {
+			form.AddInputField("Cluster URL", s.draft.DestServer, 50, nil, func(text string) {
+				s.draft.DestServer = strings.TrimSpace(text)
+			})
+		}
+		form.AddInputField("Namespace", s.draft.DestNamespace, 50, nil, func(text string) {
+			s.draft.DestNamespace = strings.TrimSpace(text)
+		})
+	case stepSync:
+		policy := &s.draft.SyncPolicy
+		form.AddCheckbox("Automated sync", policy.Automated, func(checked bool) {
+			policy.Automated = checked
+		})
+		form.AddCheckbox("Self heal", policy.SelfHeal, func(checked bool) {
+			policy.SelfHeal = checked
+		})
+		form.AddCheckbox("Prune", policy.Prune, func(checked bool) {
+			policy.Prune = checked
+		})
+		for _, option := range syncOptions {
+			option := option
+			form.AddCheckbox(option, hasOption(policy.SyncOptions, option), func(checked bool) {
+				policy.SyncOptions = setOption(policy.SyncOptions, option, checked)
+			})
+		}
+	}
+
+	if s.step > stepGeneral {
+		form.AddButton("Back", func() {
+			s.step--
+			s.showStep()
+		})
+	}
+	form.AddButton("Next", func() {
+		if err := s.validateStep(); err != nil {
+			s.statusView.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+			return
+		}
+		s.statusView.SetText("")
+		s.step++
+		s.showStep()
+	})
+	form.AddButton("Cancel", s.cancel)
+
+	s.content.AddItem(form, 0, 1, true)
+	s.app.SetFocus(form)
+}
+
+func (s *ScreenCreateApp) newForm() *tview.Form {
+	form := tview.NewForm().
+		SetFieldBackgroundColor(tcell.NewHexColor(0x1a1a1a)).
+		SetFieldTextColor(tcell.ColorWhite).
+		SetLabelColor(tcell.NewHexColor(0x00bebe)).
+		SetButtonBackgroundColor(tcell.NewHexColor(0x017be9)).
+		SetButtonTextColor(tcell.ColorWhite)
+	form.SetBackgroundColor(tcell.NewHexColor(0x000000))
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			s.cancel()
+			return nil
+		}
+		return event
+	})
+	return form
+}
+
+func (s *ScreenCreateApp) showPreview() {
+	manifest, err := argocd.NewAppManifest(s.draft)
+	if err != nil {
+		s.statusView.SetText(fmt.Sprintf("[red]Error rendering manifest: %s[-]", tview.Escape(err.Error())))
+		s.step--
+		s.showStep()
+		return
+	}
+	s.manifest = manifest
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetText(components.HighlightYAML(manifest))
+	view.SetBackgroundColor(tcell.NewHexColor(0x000000))
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEnter:
+			s.create()
+			return nil
+		case tcell.KeyEscape, tcell.KeyBackspace, tcell.KeyBackspace2:
+			s.step--
+			s.showStep()
+			return nil
+		}
+		return event
+	})
+	s.content.AddItem(view, 0, 1, true)
+	s.app.SetFocus(view)
+	s.statusView.SetText("[#017be9]Enter[-] create   [#017be9]Esc[-] back")
+}
+
+func (s *ScreenCreateApp) create() {
+	if err := s.client.CreateApp(s.manifest); err != nil {
+		modal := components.ErrorModal(
+			fmt.Sprintf("Error creating application %s:", s.draft.Name),
+			err.Error(),
+			func() {
+				s.app.SetRoot(s.pages, true)
+				s.showStep()
+			},
+		)
+		s.app.SetRoot(modal, true)
+		return
+	}
+	s.onClose()
+}
+
+func (s *ScreenCreateApp) cancel() {
+	s.onClose()
+}
+
+// validateStep checks the fields of the current step.
+func (s *ScreenCreateApp) validateStep() error {
+	d := &s.draft
+	switch s.step {
+	case stepGeneral:
+		if err := argocd.ValidateAppName(d.Name); err != nil {
+			return err
+		}
+		if _, err := s.client.GetApp(d.Name); err == nil {
+			return fmt.Errorf("application %s already exists", d.Name)
+		}
+		if d.Project == "" {
+			return errors.New("project is required")
+		}
+		if s.projects != nil && s.project() == nil {
+			return fmt.Errorf("project %s does not exist", d.Project)
+		}
+	case stepSource:
+		if d.RepoURL == "" {
+			return errors.New("repository URL is required")
+		}
+		helm := d.Chart != ""
+		if s.repos != nil {
+			repo := s.repo(d.RepoURL)
+			if repo == nil {
+				return fmt.Errorf("repository %s is not registered in ArgoCD", d.RepoURL)
+			}
+			helm = repo.Type == "helm"
+		}
+		if p := s.project(); p != nil && !p.AllowsSource(d.RepoURL) {
+			return fmt.Errorf("project %s does not allow repository %s", p.Name, d.RepoURL)
+		}
+		switch {
+		case helm && d.Chart == "":
+			return errors.New("chart is required for Helm repositories")
+		case helm && d.Path != "":
+			return errors.New("Helm repositories take a chart, not a path")
+		case helm && (d.TargetRevision == "" || d.TargetRevision == "HEAD"):
+			return errors.New("target revision must be a chart version")
+		case !helm && d.Chart != "":
+			return errors.New("charts are only available from Helm repositories")
+		case !helm && d.Path == "":
+			return errors.New("path is required, use . for the repository root")
+		}
+		if d.TargetRevision == "" {
+			d.TargetRevision = "HEAD"
+		}
+	case stepDestination:
+		if d.DestServer == "" {
+			return errors.New("cluster is required")
+		}
+		if d.DestNamespace == "" {
+			return errors.New("namespace is required")
+		}
+		if p := s.project(); p != nil && !p.AllowsDestination(d.DestServer, s.clusterName(d.DestServer), d.DestNamespace) {
+			return fmt.Errorf("project %s does not allow deploying to %s in %s", p.Name, d.DestServer, d.DestNamespace)
+		}
+	case stepSync:
+		if !d.SyncPolicy.Automated {
+			d.SyncPolicy.Prune, d.SyncPolicy.SelfHeal = false, false
+		}
+	}
+	return nil
+}
+
+func (s *ScreenCreateApp) project() *argocd.Project {
+	for i := range s.projects {
+		if s.projects[i].Name == s.draft.Project {
+			return &s.projects[i]
+		}
+	}
+	return nil
+}
+
+func (s *ScreenCreateApp) repo(url string) *argocd.Repository {
+	for i := range s.repos {
+		if strings.TrimSuffix(s.repos[i].URL, ".git") == strings.TrimSuffix(url, ".git") {
+			return &s.repos[i]
+		}
+	}
+	return nil
+}
+
+func (s *ScreenCreateApp) clusterName(server string) string {
+	for _, c := range s.clusters {
+		if c.Server == server {
+			return c.Name
+		}
+	}
+	return ""
+}
+
+func (s *ScreenCreateApp) completeRepo(text string) []string {
+	if text == "" {
+		return nil
+	}
+	var matches []string
+	for _, repo := range s.repos {
+		if strings.Contains(strings.ToLower(repo.URL), strings.ToLower(text)) {
+			matches = append(matches, repo.URL)
+		}
+	}
+	return matches
+}
+
+func hasOption(options []string, option string) bool {
+	for _, o := range options {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+func setOption(options []string, option string, enabled bool) []string {
+	var result []string
+	for _, o := range options {
+		if o != option {
+			result = append(result, o)
+		}
+	}
+	if enabled {
+		result = append(result, option)
+	}
+	return result
+}
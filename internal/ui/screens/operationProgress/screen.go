@@ -0,0 +1,413 @@
+package operationProgress
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd"
+	"github.com/Jack200062/ArguTUI/internal/ui"
+	"github.com/Jack200062/ArguTUI/internal/ui/common"
+	"github.com/Jack200062/ArguTUI/internal/ui/components"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+var syncPhaseOrder = map[string]int{
+	"PreSync":  0,
+	"Sync":     1,
+	"PostSync": 2,
+	"SyncFail": 3,
+}
+
+// ScreenOperationProgress follows the current (or last) operation of an
+// application: phase, sync-wave progress and the result of every resource.
+type ScreenOperationProgress struct {
+	app          *tview.Application
+	instanceInfo *common.InstanceInfo
+	client       argocd.Backend
+	router       *ui.Router
+
+	appName string
+
+	pages      *tview.Pages
+	header     *tview.TextView
+	table      *tview.Table
+	statusView *tview.TextView
+
+	current argocd.Application
+	loaded  bool
+	// waves maps resource keys to their sync wave
+	waves       map[string]int
+	watchCancel context.CancelFunc
+}
+
+func New(
+	app *tview.Application,
+	r *ui.Router,
+	instanceInfo *common.InstanceInfo,
+	client argocd.Backend,
+	appName string,
+) *ScreenOperationProgress {
+	return &ScreenOperationProgress{
+		app:          app,
+		instanceInfo: instanceInfo,
+		client:       client,
+		router:       r,
+		appName:      appName,
+	}
+}
+
+func (s *ScreenOperationProgress) Init() tview.Primitive {
+	textColor := tcell.NewHexColor(0x00bebe)
+	backgroundColor := tcell.NewHexColor(0x000000)
+	borderColor := tcell.NewHexColor(0x63a0bf)
+	shortcutKeyColor := tcell.NewHexColor(0x017be9)
+	selectedBgColor := tcell.NewHexColor(0x373737)
+
+	instanceView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(s.instanceInfo.FormattedString(tcell.ColorYellow)).
+		SetTextAlign(tview.AlignLeft)
+	instanceView.SetBackgroundColor(backgroundColor)
+
+	shortcutBar := components.NewShortcutBar(backgroundColor, shortcutKeyColor)
+	shortcutBar.AddGroup("Operation", map[string]string{
+		"T": "Terminate",
+		"r": "Reload waves",
+	})
+	shortcutBar.AddGroup("Navigation", map[string]string{
+		"b": "Back",
+		"q": "Quit",
+	})
+
+	topBar := tview.NewFlex().
+		SetDirection(tview.FlexColumn).
+		AddItem(instanceView, 0, 1, false).
+		AddItem(shortcutBar.Init(), 0, 2, false)
+	topBar.SetBackgroundColor(backgroundColor)
+
+	s.header = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true)
+	s.header.SetBackgroundColor(backgroundColor)
+
+	s.table = tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSelectedStyle(tcell.StyleDefault.
+			Background(selectedBgColor).
+			Foreground(textColor))
+	s.table.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Operation of %s ", s.appName)).
+		SetTitleAlign(tview.AlignCenter).
+		SetTitleColor(textColor).
+		SetBorderColor(borderColor).
+		SetBackgroundColor(backgroundColor)
+	s.table.SetInputCapture(s.onKey)
+
+	s.statusView = tview.NewTextView().SetDynamicColors(true)
+	s.statusView.SetBackgroundColor(backgroundColor)
+
+	grid := tview.NewGrid().
+		SetRows(3, 5, 0, 1).
+		SetColumns(0).
+		SetBorders(true)
+	grid.AddItem(topBar, 0, 0, 1, 1, 0, 0, false).
+		AddItem(s.header, 1, 0, 1, 1, 0, 0, false).
+		AddItem(s.table, 2, 0, 1, 1, 0, 0, true).
+		AddItem(s.statusView, 3, 0, 1, 1, 0, 0, false)
+
+	s.pages = tview.NewPages().
+		AddPage("main", grid, true, true)
+
+	if s.waves == nil {
+		s.loadWaves()
+	}
+	if s.loaded {
+		s.render()
+	} else {
+		s.header.SetText("[gray]Waiting for application state...[-]")
+	}
+	s.startWatch()
+	return s.pages
+}
+
+// startWatch follows the application stream until the screen is left.
+func (s *ScreenOperationProgress) startWatch() {
+	if s.watchCancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.watchCancel = cancel
+
+	go s.client.WatchApp(ctx, s.appName,
+		func(app argocd.Application) {
+			s.app.QueueUpdateDraw(func() {
+				if ctx.Err() == nil {
+					s.current = app
+					s.loaded = true
+					s.render()
+				}
+			})
+		},
+		func(connected bool, err error) {
+			s.app.QueueUpdateDraw(func() {
+				if ctx.Err() != nil {
+					return
+				}
+				if err != nil {
+					s.statusView.SetText(fmt.Sprintf("[red]Stream interrupted: %s[-]", tview.Escape(err.Error())))
+				} else {
+					s.statusView.SetText("[gray]live[-]")
+				}
+			})
+		},
+	)
+}
+
+func (s *ScreenOperationProgress) stopWatch() {
+	if s.watchCancel != nil {
+		s.watchCancel()
+		s.watchCancel = nil
+	}
+}
+
+// loadWaves reads the sync wave of every managed resource. Results only
+// name the resource, so the waves are needed to show progress per wave.
+func (s *ScreenOperationProgress) loadWaves() {
+	diffs, err := s.client.GetResourceDiffs(s.appName)
+	if err != nil {
+		s.statusView.SetText(fmt.Sprintf("[red]Error loading sync waves: %s[-]", tview.Escape(err.Error())))
+		return
+	}
+	s.waves = make(map[string]int, len(diffs))
+	for _, d := range diffs {
+		s.waves[resourceKey(d.Group, d.Kind, d.Namespace, d.Name)] = d.SyncWave
+	}
+}
+
+func (s *ScreenOperationProgress) render() {
+	op := s.current.Operation
+	if op == nil {
+		s.header.SetText("[gray]No operation has run for this application yet[-]")
+		s.table.Clear()
+		return
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "[gray]Phase:[-]    [%s::b]%s[-:-:-]\n", phaseColor(op.Phase), tview.Escape(op.Phase))
+	fmt.Fprintf(&b, "[gray]Message:[-]  %s\n", tview.Escape(op.Message))
+	fmt.Fprintf(&b, "[gray]Revision:[-] %s\n", tview.Escape(op.Revision))
+	fmt.Fprintf(&b, "[gray]Started:[-]  %s (%s)\n", op.StartedAt.Local().Format("15:04:05"), s.duration(op))
+	fmt.Fprintf(&b, "[gray]Progress:[-] %s", s.waveProgress(op))
+	s.header.SetText(b.String())
+
+	s.fillTable(op.Resources)
+}
+
+func (s *ScreenOperationProgress) duration(op *argocd.OperationState) string {
+	end := op.FinishedAt
+	if end.IsZero() {
+		end = time.Now()
+	}
+	return end.Sub(op.StartedAt).Round(time.Second).String()
+}
+
+// waveProgress summarizes how far the sync got. A wave is done once every
+// resource in it has a final result.
+func (s *ScreenOperationProgress) waveProgress(op *argocd.OperationState) string {
+	done := make(map[string]bool, len(op.Resources))
+	for _, res := range op.Resources {
+		if isFinished(res) {
+			done[resourceKey(res.Group, res.Kind, res.Namespace, res.Name)] = true
+		}
+	}
+
+	pending := make(map[int]int)
+	var waves []int
+	for key, wave := range s.waves {
+		if _, ok := pending[wave]; !ok {
+			pending[wave] = 0
+			waves = append(waves, wave)
+		}
+		if !done[key] {
+			pending[wave]++
+		}
+	}
+	sort.Ints(waves)
+
+	finished := 0
+	for _, res := range op.Resources {
+		if isFinished(res) {
+			finished++
+		}
+	}
+	summary := fmt.Sprintf("%d/%d resources done", finished, max(len(op.Resources), len(s.waves)))
+	if len(waves) == 0 || op.Phase != "Running" {
+		return summary
+	}
+	for i, wave := range waves {
+		if pending[wave] > 0 {
+			return fmt.Sprintf("wave %d (%d of %d), %s", wave, i+1, len(waves), summary)
+		}
+	}
+	return fmt.Sprintf("all %d waves applied, %s", len(waves), summary)
+}
+
+func (s *ScreenOperationProgress) fillTable(results []argocd.ResourceResult) {
+	row, _ := s.table.GetSelection()
+	s.table.Clear()
+
+	headers := []string{"Phase", "Wave", "Kind", "Namespace", "Name", "Status", "Hook", "Message"}
+	for col, h := range headers {
+		s.table.SetCell(0, col, tview.NewTableCell(fmt.Sprintf("[::b]%s", h)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false))
+	}
+
+	sorted := make([]argocd.ResourceResult, len(results))
+	copy(sorted, results)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if syncPhaseOrder[a.SyncPhase] != syncPhaseOrder[b.SyncPhase] {
+			return syncPhaseOrder[a.SyncPhase] < syncPhaseOrder[b.SyncPhase]
+		}
+		wa := s.waves[resourceKey(a.Group, a.Kind, a.Namespace, a.Name)]
+		wb := s.waves[resourceKey(b.Group, b.Kind, b.Namespace, b.Name)]
+		if wa != wb {
+			return wa < wb
+		}
+		return a.Kind+"/"+a.Name < b.Kind+"/"+b.Name
+	})
+
+	for i, res := range sorted {
+		hook := res.HookType
+		if hook != "" && res.HookPhase != "" {
+			hook += " (" + res.HookPhase + ")"
+		}
+		cells := []string{
+			res.SyncPhase,
+			fmt.Sprintf("%d", s.waves[resourceKey(res.Group, res.Kind, res.Namespace, res.Name)]),
+			res.Kind,
+			res.Namespace,
+			res.Name,
+			res.Status,
+			hook,
+			res.Message,
+		}
+		color := resultColor(res)
+		for col, text := range cells {
+			cell := tview.NewTableCell(tview.Escape(text)).SetTextColor(color)
+			if col == len(cells)-1 {
+				cell.SetExpansion(1)
+			}
+			s.table.SetCell(i+1, col, cell)
+		}
+	}
+
+	if len(sorted) > 0 {
+		if row < 1 {
+			row = 1
+		}
+		s.table.Select(min(row, len(sorted)), 0)
+	}
+}
+
+func (s *ScreenOperationProgress) onKey(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Rune() {
+	case 'b':
+		s.stopWatch()
+		s.router.Back()
+		return nil
+	case 'r':
+		s.loadWaves()
+		s.render()
+		return nil
+	case 'T':
+		s.confirmTerminate()
+		return nil
+	}
+	return event
+}
+
+func (s *ScreenOperationProgress) confirmTerminate() {
+	if s.current.Operation == nil || s.current.Operation.Phase != "Running" {
+		s.statusView.SetText("[yellow]No operation is in progress[-]")
+		return
+	}
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Terminate the running operation of %s?", s.appName)).
+		AddButtons([]string{"Yes", "No"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			s.router.CloseOverlay(s.pages)
+			if buttonIndex != 0 {
+				return
+			}
+			if err := s.client.TerminateOperation(s.appName); err != nil {
+				errorModal := components.ErrorModal(
+					fmt.Sprintf("Error terminating operation of %s:", s.appName),
+					err.Error(),
+					func() { s.router.CloseOverlay(s.pages) },
+				)
+				s.router.ShowOverlay(errorModal)
+				return
+			}
+			s.statusView.SetText("[yellow]Termination requested[-]")
+		})
+	modal.SetBackgroundColor(tcell.ColorDarkRed)
+	s.router.ShowOverlay(modal)
+}
+
+// isFinished reports whether res reached its final state. Hooks keep running
+// after they were created, plain resources are done once applied.
+func isFinished(res argocd.ResourceResult) bool {
+	if res.Status == "" {
+		return false
+	}
+	if res.HookType == "" {
+		return true
+	}
+	switch res.HookPhase {
+	case "Succeeded", "Failed", "Error":
+		return true
+	}
+	return false
+}
+
+func resultColor(res argocd.ResourceResult) tcell.Color {
+	switch {
+	case res.Status == "SyncFailed" || res.HookPhase == "Failed" || res.HookPhase == "Error":
+		return tcell.ColorRed
+	case !isFinished(res):
+		return tcell.ColorOrange
+	case res.Status == "PruneSkipped":
+		return tcell.ColorGray
+	default:
+		return tcell.ColorGreen
+	}
+}
+
+func phaseColor(phase string) string {
+	switch phase {
+	case "Succeeded":
+		return "green"
+	case "Running":
+		return "orange"
+	case "Terminating":
+		return "yellow"
+	default:
+		return "red"
+	}
+}
+
+func resourceKey(group, kind, namespace, name string) string {
+	return group + "|" + kind + "|" + namespace + "|" + name
+}
+
+func (s *ScreenOperationProgress) Name() string {
+	return "OperationProgress"
+}
@@ -0,0 +1,486 @@
+package podLogs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd"
+	"github.com/Jack200062/ArguTUI/internal/ui"
+	"github.com/Jack200062/ArguTUI/internal/ui/common"
+	"github.com/Jack200062/ArguTUI/internal/ui/components"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	tailLines = 500
+	// maxLines bounds the buffer of a long running follow
+	maxLines      = 10000
+	flushInterval = 250 * time.Millisecond
+)
+
+// ScreenPodLogs streams the logs of a Pod, or of all pods of a workload.
+type ScreenPodLogs struct {
+	app          *tview.Application
+	instanceInfo *common.InstanceInfo
+	client       argocd.Backend
+	router       *ui.Router
+
+	appName string
+	ref     argocd.ResourceRef
+
+	grid        *tview.Grid
+	view        *tview.TextView
+	statusView  *tview.TextView
+	filterInput *tview.InputField
+
+	containers []string
+	container  string
+	follow     bool
+	previous   bool
+	timestamps bool
+	filter     string
+
+	// lines is only accessed from the UI goroutine, the stream appends to pending
+	lines     []argocd.LogLine
+	unseen    int
+	pendingMu sync.Mutex
+	pending   []argocd.LogLine
+
+	streamCancel context.CancelFunc
+	streamState  string
+}
+
+func New(
+	app *tview.Application,
+	r *ui.Router,
+	instanceInfo *common.InstanceInfo,
+	client argocd.Backend,
+	appName string,
+	ref argocd.ResourceRef,
+) *ScreenPodLogs {
+	return &ScreenPodLogs{
+		app:          app,
+		instanceInfo: instanceInfo,
+		client:       client,
+		router:       r,
+		appName:      appName,
+		ref:          ref,
+		follow:       true,
+	}
+}
+
+func (s *ScreenPodLogs) Init() tview.Primitive {
+	textColor := tcell.NewHexColor(0x00bebe)
+	backgroundColor := tcell.NewHexColor(0x000000)
+	borderColor := tcell.NewHexColor(0x63a0bf)
+	shortcutKeyColor := tcell.NewHexColor(0x017be9)
+
+	instanceView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(s.instanceInfo.FormattedString(tcell.ColorYellow)).
+		SetTextAlign(tview.AlignLeft)
+	instanceView.SetBackgroundColor(backgroundColor)
+
+	shortcutBar := components.NewShortcutBar(backgroundColor, shortcutKeyColor)
+	shortcutBar.AddGroup("Logs", map[string]string{
+		"f": "Follow/Pause",
+		"c": "Container",
+		"/": "Grep",
+		"t": "Timestamps",
+	})
+	shortcutBar.AddGroup("More", map[string]string{
+		"P": "Previous",
+		"s": "Save",
+		"b": "Back",
+	})
+
+	topBar := tview.NewFlex().
+		SetDirection(tview.FlexColumn).
+		AddItem(instanceView, 0, 1, false).
+		AddItem(shortcutBar.Init(), 0, 2, false)
+	topBar.SetBackgroundColor(backgroundColor)
+
+	s.view = tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true).
+		SetMaxLines(maxLines)
+	s.view.SetBackgroundColor(backgroundColor)
+	s.view.SetBorder(true).
+		SetBorderColor(borderColor).
+		SetTitleColor(textColor).
+		SetTitleAlign(tview.AlignCenter)
+	s.view.SetInputCapture(s.onKey)
+
+	s.statusView = tview.NewTextView().SetDynamicColors(true)
+	s.statusView.SetBackgroundColor(backgroundColor)
+
+	s.filterInput = tview.NewInputField().
+		SetLabel("grep: ").
+		SetFieldBackgroundColor(tcell.NewHexColor(0x1a1a1a)).
+		SetLabelColor(textColor)
+	s.filterInput.SetBackgroundColor(backgroundColor)
+	s.filterInput.SetDoneFunc(s.filterDone)
+
+	s.grid = tview.NewGrid().
+		SetRows(3, 0, 1).
+		SetColumns(0).
+		SetBorders(true)
+	s.grid.AddItem(topBar, 0, 0, 1, 1, 0, 0, false).
+		AddItem(s.view, 1, 0, 1, 1, 0, 0, true).
+		AddItem(s.statusView, 2, 0, 1, 1, 0, 0, false)
+
+	if s.containers == nil {
+		s.loadContainers()
+	}
+	s.render()
+	s.startStream()
+	return s.grid
+}
+
+func (s *ScreenPodLogs) loadContainers() {
+	containers, err := s.client.GetContainers(s.appName, s.ref)
+	if err != nil {
+		s.setStatus(fmt.Sprintf("[red]Error listing containers: %s[-]", tview.Escape(err.Error())))
+		return
+	}
+	s.containers = containers
+	if s.container == "" && len(containers) > 0 {
+		s.container = containers[0]
+	}
+}
+
+// startStream (re)opens the log stream. Init runs again after modals close,
+// so an already running stream is kept.
+func (s *ScreenPodLogs) startStream() {
+	if s.streamCancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.streamCancel = cancel
+	s.streamState = "streaming"
+	s.updateTitle()
+
+	opts := argocd.LogOptions{
+		Container: s.container,
+		Follow:    !s.previous,
+		Previous:  s.previous,
+		TailLines: tailLines,
+	}
+
+	go func() {
+		ticker := time.NewTicker(flushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.app.QueueUpdateDraw(func() {
+					if ctx.Err() == nil {
+						s.flush()
+					}
+				})
+			}
+		}
+	}()
+
+	go func() {
+		err := s.client.StreamPodLogs(ctx, s.appName, s.ref, opts, func(line argocd.LogLine) {
+			if ctx.Err() != nil {
+				return
+			}
+			s.pendingMu.Lock()
+			s.pending = append(s.pending, line)
+			s.pendingMu.Unlock()
+		})
+		s.app.QueueUpdateDraw(func() {
+			if ctx.Err() != nil {
+				return
+			}
+			s.flush()
+			if err != nil {
+				s.streamState = "error"
+				s.setStatus(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+			} else {
+				s.streamState = "ended"
+			}
+			s.updateTitle()
+		})
+	}()
+}
+
+func (s *ScreenPodLogs) stopStream() {
+	if s.streamCancel != nil {
+		s.streamCancel()
+		s.streamCancel = nil
+	}
+	s.pendingMu.Lock()
+	s.pending = nil
+	s.pendingMu.Unlock()
+}
+
+// restartStream drops the buffer and reconnects with the current options.
+func (s *ScreenPodLogs) restartStream() {
+	s.stopStream()
+	s.lines = nil
+	s.unseen = 0
+	s.render()
+	s.startStream()
+}
+
+// flush moves lines received by the stream into the buffer. New lines are
+// appended to the view only while following.
+func (s *ScreenPodLogs) flush() {
+	s.pendingMu.Lock()
+	pending := s.pending
+	s.pending = nil
+	s.pendingMu.Unlock()
+	if len(pending) == 0 {
+		return
+	}
+
+	s.lines = append(s.lines, pending...)
+	if len(s.lines) > maxLines {
+		s.lines = append([]argocd.LogLine(nil), s.lines[len(s.lines)-maxLines:]...)
+	}
+
+	if !s.follow {
+		s.unseen += len(pending)
+		s.updateTitle()
+		return
+	}
+	var out strings.Builder
+	for _, line := range pending {
+		if s.matches(line) {
+			out.WriteString(s.format(line, true))
+		}
+	}
+	fmt.Fprint(s.view, out.String())
+	s.view.ScrollToEnd()
+}
+
+// render redraws the whole buffer, used when the filter or format changes.
+func (s *ScreenPodLogs) render() {
+	var out strings.Builder
+	for _, line := range s.lines {
+		if s.matches(line) {
+			out.WriteString(s.format(line, true))
+		}
+	}
+	s.view.SetText(out.String())
+	if s.follow {
+		s.view.ScrollToEnd()
+	}
+	s.updateTitle()
+}
+
+func (s *ScreenPodLogs) matches(line argocd.LogLine) bool {
+	return s.filter == "" || strings.Contains(strings.ToLower(line.Content), strings.ToLower(s.filter))
+}
+
+// format renders line with the enabled prefixes. Workloads merge the logs of
+// several pods, so the pod name is shown for them.
+func (s *ScreenPodLogs) format(line argocd.LogLine, colored bool) string {
+	var b strings.Builder
+	if s.timestamps && !line.TimeStamp.IsZero() {
+		ts := line.TimeStamp.Format(time.RFC3339)
+		if colored {
+			ts = "[gray]" + ts + "[-]"
+		}
+		b.WriteString(ts + " ")
+	}
+	if s.ref.Kind != "Pod" && line.PodName != "" {
+		pod := line.PodName
+		if colored {
+			pod = "[#63a0bf]" + tview.Escape(pod) + "[-]"
+		}
+		b.WriteString(pod + " ")
+	}
+	if colored {
+		b.WriteString(tview.Escape(line.Content))
+	} else {
+		b.WriteString(line.Content)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+func (s *ScreenPodLogs) updateTitle() {
+	if s.view == nil {
+		return
+	}
+	var flags []string
+	if s.container != "" {
+		flags = append(flags, "container: "+s.container)
+	}
+	if s.previous {
+		flags = append(flags, "previous")
+	}
+	if s.filter != "" {
+		flags = append(flags, "grep: "+s.filter)
+	}
+	switch {
+	case s.streamState != "streaming":
+		flags = append(flags, s.streamState)
+	case !s.follow && s.unseen > 0:
+		flags = append(flags, fmt.Sprintf("paused, %d new", s.unseen))
+	case !s.follow:
+		flags = append(flags, "paused")
+	default:
+		flags = append(flags, "following")
+	}
+	s.view.SetTitle(fmt.Sprintf(" Logs: %s/%s [%s] ",
+		s.ref.Kind, s.ref.Name, tview.Escape(strings.Join(flags, " | "))))
+}
+
+func (s *ScreenPodLogs) setStatus(text string) {
+	if s.statusView != nil {
+		s.statusView.SetText(text)
+	}
+}
+
+func (s *ScreenPodLogs) onKey(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Rune() {
+	case 'b':
+		s.stopStream()
+		s.router.Back()
+		return nil
+	case 'f':
+		s.toggleFollow()
+		return nil
+	case 't':
+		s.timestamps = !s.timestamps
+		s.render()
+		return nil
+	case 'P':
+		s.previous = !s.previous
+		s.restartStream()
+		return nil
+	case 'c':
+		s.showContainerPicker()
+		return nil
+	case '/':
+		s.showFilterInput()
+		return nil
+	case 's':
+		s.showSaveDialog()
+		return nil
+	}
+	return event
+}
+
+func (s *ScreenPodLogs) toggleFollow() {
+	s.follow = !s.follow
+	if s.follow {
+		s.unseen = 0
+		s.render()
+		return
+	}
+	s.updateTitle()
+}
+
+func (s *ScreenPodLogs) showContainerPicker() {
+	if len(s.containers) == 0 {
+		s.loadContainers()
+	}
+	if len(s.containers) < 2 {
+		s.setStatus("[yellow]No other containers to pick from[-]")
+		return
+	}
+
+	list := tview.NewList().ShowSecondaryText(false)
+	list.SetBorder(true).
+		SetTitle(" Container ").
+		SetBorderColor(tcell.NewHexColor(0x63a0bf))
+	list.SetBackgroundColor(tcell.NewHexColor(0x000000))
+	list.SetSelectedBackgroundColor(tcell.NewHexColor(0x373737))
+	for i, name := range s.containers {
+		name := name
+		list.AddItem(name, "", 0, func() {
+			s.router.CloseOverlay(s.grid)
+			s.app.SetFocus(s.view)
+			if name != s.container {
+				s.container = name
+				s.restartStream()
+			}
+		})
+		if name == s.container {
+			list.SetCurrentItem(i)
+		}
+	}
+	list.SetDoneFunc(func() {
+		s.router.CloseOverlay(s.grid)
+		s.app.SetFocus(s.view)
+	})
+
+	s.router.ShowOverlay(components.Centered(list, 40, len(s.containers)+2))
+}
+
+// showFilterInput puts the grep field under the log view. The screen is shown
+// as an overlay so that every key, including 'q', reaches the input field.
+func (s *ScreenPodLogs) showFilterInput() {
+	s.filterInput.SetText(s.filter)
+	s.grid.RemoveItem(s.statusView)
+	s.grid.AddItem(s.filterInput, 2, 0, 1, 1, 0, 0, false)
+	s.router.ShowOverlay(s.grid)
+	s.app.SetFocus(s.filterInput)
+}
+
+func (s *ScreenPodLogs) filterDone(key tcell.Key) {
+	if key == tcell.KeyEnter {
+		s.filter = s.filterInput.GetText()
+		s.render()
+	}
+	s.grid.RemoveItem(s.filterInput)
+	s.grid.AddItem(s.statusView, 2, 0, 1, 1, 0, 0, false)
+	s.router.CloseOverlay(s.grid)
+	s.app.SetFocus(s.view)
+}
+
+func (s *ScreenPodLogs) showSaveDialog() {
+	name := s.ref.Name
+	if s.container != "" {
+		name += "-" + s.container
+	}
+	defaultPath := fmt.Sprintf("%s-%s.log", name, time.Now().Format("20060102-150405"))
+
+	dialog := components.SaveFileModal("Save logs", defaultPath,
+		func(path string) {
+			s.router.CloseOverlay(s.grid)
+			s.app.SetFocus(s.view)
+			s.saveBuffer(path)
+		},
+		func() {
+			s.router.CloseOverlay(s.grid)
+			s.app.SetFocus(s.view)
+		},
+	)
+	s.router.ShowOverlay(dialog)
+}
+
+// saveBuffer writes the lines currently matching the filter to path.
+func (s *ScreenPodLogs) saveBuffer(path string) {
+	var out strings.Builder
+	count := 0
+	for _, line := range s.lines {
+		if s.matches(line) {
+			out.WriteString(s.format(line, false))
+			count++
+		}
+	}
+	if err := os.WriteFile(path, []byte(out.String()), 0o644); err != nil {
+		s.setStatus(fmt.Sprintf("[red]Error saving logs: %s[-]", tview.Escape(err.Error())))
+		return
+	}
+	s.setStatus(fmt.Sprintf("[green]Saved %d lines to %s[-]", count, tview.Escape(path)))
+}
+
+func (s *ScreenPodLogs) Name() string {
+	return "PodLogs"
+}
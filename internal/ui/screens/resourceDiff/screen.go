@@ -0,0 +1,225 @@
+package resourceDiff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd"
+	"github.com/Jack200062/ArguTUI/internal/ui"
+	"github.com/Jack200062/ArguTUI/internal/ui/common"
+	"github.com/Jack200062/ArguTUI/internal/ui/components"
+	"github.com/Jack200062/ArguTUI/pkg/diff"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const contextLines = 3
+
+// Target identifies a single resource of the application.
+type Target struct {
+	Group     string
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// ScreenResourceDiff shows the difference between the live and the desired
+// state of one resource, or of every OutOfSync resource of an application.
+type ScreenResourceDiff struct {
+	app          *tview.Application
+	instanceInfo *common.InstanceInfo
+	client       argocd.Backend
+	router       *ui.Router
+
+	appName string
+	// target is nil for the app-wide diff
+	target *Target
+
+	view *tview.TextView
+	// sectionRows holds the first line of every resource in view
+	sectionRows []int
+}
+
+func New(
+	app *tview.Application,
+	r *ui.Router,
+	instanceInfo *common.InstanceInfo,
+	client argocd.Backend,
+	appName string,
+	target *Target,
+) *ScreenResourceDiff {
+	return &ScreenResourceDiff{
+		app:          app,
+		instanceInfo: instanceInfo,
+		client:       client,
+		router:       r,
+		appName:      appName,
+		target:       target,
+	}
+}
+
+func (s *ScreenResourceDiff) Init() tview.Primitive {
+	textColor := tcell.NewHexColor(0x00bebe)
+	backgroundColor := tcell.NewHexColor(0x000000)
+	borderColor := tcell.NewHexColor(0x63a0bf)
+	shortcutKeyColor := tcell.NewHexColor(0x017be9)
+
+	instanceView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(s.instanceInfo.FormattedString(tcell.ColorYellow)).
+		SetTextAlign(tview.AlignLeft)
+	instanceView.SetBackgroundColor(backgroundColor)
+
+	shortcutBar := components.NewShortcutBar(backgroundColor, shortcutKeyColor)
+	shortcutBar.AddGroup("Diff", map[string]string{
+		"n/p": "Next/Prev resource",
+		"r":   "Reload",
+	})
+	shortcutBar.AddGroup("Navigation", map[string]string{
+		"b": "Back",
+		"q": "Quit",
+	})
+
+	topBar := tview.NewFlex().
+		SetDirection(tview.FlexColumn).
+		AddItem(instanceView, 0, 1, false).
+		AddItem(shortcutBar.Init(), 0, 2, false)
+	topBar.SetBackgroundColor(backgroundColor)
+
+	s.view = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(false).
+		SetScrollable(true)
+	s.view.SetBackgroundColor(backgroundColor)
+	s.view.SetBorder(true).
+		SetBorderColor(borderColor).
+		SetTitleColor(textColor).
+		SetTitleAlign(tview.AlignCenter)
+	s.view.SetInputCapture(s.onKey)
+
+	grid := tview.NewGrid().
+		SetRows(3, 0).
+		SetColumns(0).
+		SetBorders(true)
+	grid.AddItem(topBar, 0, 0, 1, 1, 0, 0, false).
+		AddItem(s.view, 1, 0, 1, 1, 0, 0, true)
+
+	s.load()
+	return grid
+}
+
+func (s *ScreenResourceDiff) onKey(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Rune() {
+	case 'b':
+		s.router.Back()
+		return nil
+	case 'r':
+		s.load()
+		return nil
+	case 'n':
+		s.jumpSection(1)
+		return nil
+	case 'p':
+		s.jumpSection(-1)
+		return nil
+	}
+	return event
+}
+
+func (s *ScreenResourceDiff) load() {
+	diffs, err := s.client.GetResourceDiffs(s.appName)
+	if err != nil {
+		s.view.SetTitle(fmt.Sprintf(" Diff: %s ", s.appName))
+		s.view.SetText(fmt.Sprintf("[red]Error loading diff: %s[-]", tview.Escape(err.Error())))
+		s.sectionRows = nil
+		return
+	}
+
+	var body strings.Builder
+	s.sectionRows = nil
+	row := 0
+	for _, d := range diffs {
+		if !s.matches(d) {
+			continue
+		}
+		text := diff.Unified("live", "desired", d.LiveState, d.TargetState, contextLines)
+		if text == "" {
+			continue
+		}
+		if row > 0 {
+			body.WriteString("\n")
+			row++
+		}
+		s.sectionRows = append(s.sectionRows, row)
+		fmt.Fprintf(&body, "[yellow::b]%s[-:-:-]\n", tview.Escape(resourceTitle(d)))
+		row++
+		for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+			body.WriteString(components.ColorizeDiffLine(line))
+			body.WriteString("\n")
+			row++
+		}
+	}
+
+	s.view.SetTitle(s.title(len(s.sectionRows)))
+	if len(s.sectionRows) == 0 {
+		s.view.SetText("[green]Live state matches the desired state[-]")
+		return
+	}
+	s.view.SetText(body.String())
+	s.view.ScrollToBeginning()
+}
+
+func (s *ScreenResourceDiff) matches(d argocd.ResourceDiff) bool {
+	if s.target == nil {
+		return d.Modified
+	}
+	return d.Group == s.target.Group &&
+		d.Kind == s.target.Kind &&
+		d.Namespace == s.target.Namespace &&
+		d.Name == s.target.Name
+}
+
+func (s *ScreenResourceDiff) title(sections int) string {
+	if s.target != nil {
+		return fmt.Sprintf(" Diff: %s/%s ", s.target.Kind, s.target.Name)
+	}
+	return fmt.Sprintf(" Diff: %s (%d OutOfSync) ", s.appName, sections)
+}
+
+// jumpSection scrolls to the next (dir > 0) or previous resource in the diff.
+func (s *ScreenResourceDiff) jumpSection(dir int) {
+	if len(s.sectionRows) == 0 {
+		return
+	}
+	current, _ := s.view.GetScrollOffset()
+	if dir > 0 {
+		for _, row := range s.sectionRows {
+			if row > current {
+				s.view.ScrollTo(row, 0)
+				return
+			}
+		}
+		return
+	}
+	for i := len(s.sectionRows) - 1; i >= 0; i-- {
+		if s.sectionRows[i] < current {
+			s.view.ScrollTo(s.sectionRows[i], 0)
+			return
+		}
+	}
+}
+
+func resourceTitle(d argocd.ResourceDiff) string {
+	switch {
+	case d.LiveState == "":
+		return fmt.Sprintf("%s %s/%s (missing)", d.Kind, d.Namespace, d.Name)
+	case d.TargetState == "":
+		return fmt.Sprintf("%s %s/%s (requires pruning)", d.Kind, d.Namespace, d.Name)
+	default:
+		return fmt.Sprintf("%s %s/%s", d.Kind, d.Namespace, d.Name)
+	}
+}
+
+func (s *ScreenResourceDiff) Name() string {
+	return "ResourceDiff"
+}
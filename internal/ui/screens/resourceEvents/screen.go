@@ -0,0 +1,257 @@
+package resourceEvents
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd"
+	"github.com/Jack200062/ArguTUI/internal/ui"
+	"github.com/Jack200062/ArguTUI/internal/ui/common"
+	"github.com/Jack200062/ArguTUI/internal/ui/components"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const refreshInterval = 5 * time.Second
+
+// ScreenResourceEvents lists the Kubernetes events of a resource, or of the
+// application itself, newest first.
+type ScreenResourceEvents struct {
+	app          *tview.Application
+	instanceInfo *common.InstanceInfo
+	client       argocd.Backend
+	router       *ui.Router
+
+	appName string
+	// ref is nil for the events of the application
+	ref *argocd.ResourceRef
+
+	table      *tview.Table
+	statusView *tview.TextView
+
+	events        []argocd.Event
+	lastRefresh   time.Time
+	refreshCancel context.CancelFunc
+}
+
+func New(
+	app *tview.Application,
+	r *ui.Router,
+	instanceInfo *common.InstanceInfo,
+	client argocd.Backend,
+	appName string,
+	ref *argocd.ResourceRef,
+) *ScreenResourceEvents {
+	return &ScreenResourceEvents{
+		app:          app,
+		instanceInfo: instanceInfo,
+		client:       client,
+		router:       r,
+		appName:      appName,
+		ref:          ref,
+	}
+}
+
+func (s *ScreenResourceEvents) Init() tview.Primitive {
+	textColor := tcell.NewHexColor(0x00bebe)
+	backgroundColor := tcell.NewHexColor(0x000000)
+	borderColor := tcell.NewHexColor(0x63a0bf)
+	shortcutKeyColor := tcell.NewHexColor(0x017be9)
+	selectedBgColor := tcell.NewHexColor(0x373737)
+
+	instanceView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(s.instanceInfo.FormattedString(tcell.ColorYellow)).
+		SetTextAlign(tview.AlignLeft)
+	instanceView.SetBackgroundColor(backgroundColor)
+
+	shortcutBar := components.NewShortcutBar(backgroundColor, shortcutKeyColor)
+	shortcutBar.AddGroup("Events", map[string]string{
+		"r": "Refresh",
+	})
+	shortcutBar.AddGroup("Navigation", map[string]string{
+		"b": "Back",
+		"q": "Quit",
+	})
+
+	topBar := tview.NewFlex().
+		SetDirection(tview.FlexColumn).
+		AddItem(instanceView, 0, 1, false).
+		AddItem(shortcutBar.Init(), 0, 2, false)
+	topBar.SetBackgroundColor(backgroundColor)
+
+	s.table = tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0).
+		SetSelectedStyle(tcell.StyleDefault.
+			Background(selectedBgColor).
+			Foreground(textColor))
+	s.table.SetBorder(true).
+		SetTitle(s.title()).
+		SetTitleAlign(tview.AlignCenter).
+		SetTitleColor(textColor).
+		SetBorderColor(borderColor).
+		SetBackgroundColor(backgroundColor)
+	s.table.SetInputCapture(s.onKey)
+
+	s.statusView = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignRight)
+	s.statusView.SetBackgroundColor(backgroundColor)
+
+	grid := tview.NewGrid().
+		SetRows(3, 0, 1).
+		SetColumns(0).
+		SetBorders(true)
+	grid.AddItem(topBar, 0, 0, 1, 1, 0, 0, false).
+		AddItem(s.table, 1, 0, 1, 1, 0, 0, true).
+		AddItem(s.statusView, 2, 0, 1, 1, 0, 0, false)
+
+	s.refresh()
+	s.startAutoRefresh()
+	return grid
+}
+
+// startAutoRefresh polls the events until the screen is left. The events are
+// fetched on the ticker goroutine, only the table update runs on the UI.
+func (s *ScreenResourceEvents) startAutoRefresh() {
+	if s.refreshCancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.refreshCancel = cancel
+
+	go func() {
+		ticker := time.NewTicker(refreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				events, err := s.client.ListEvents(s.appName, s.ref)
+				s.app.QueueUpdateDraw(func() {
+					if ctx.Err() == nil {
+						s.showEvents(events, err)
+					}
+				})
+			}
+		}
+	}()
+}
+
+func (s *ScreenResourceEvents) stopAutoRefresh() {
+	if s.refreshCancel != nil {
+		s.refreshCancel()
+		s.refreshCancel = nil
+	}
+}
+
+func (s *ScreenResourceEvents) refresh() {
+	s.showEvents(s.client.ListEvents(s.appName, s.ref))
+}
+
+func (s *ScreenResourceEvents) showEvents(events []argocd.Event, err error) {
+	if err != nil {
+		s.statusView.SetText(fmt.Sprintf("[red]Error loading events: %s[-]", tview.Escape(err.Error())))
+		return
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].LastSeen.After(events[j].LastSeen)
+	})
+	s.events = events
+	s.lastRefresh = time.Now()
+	s.fillTable()
+	s.statusView.SetText(fmt.Sprintf("[gray]Events: [#63a0bf]%d[gray] | auto-refresh %s | Last update: [#ffffff]%s",
+		len(events), refreshInterval, s.lastRefresh.Format("15:04:05")))
+}
+
+func (s *ScreenResourceEvents) fillTable() {
+	row, _ := s.table.GetSelection()
+	s.table.Clear()
+
+	headers := []string{"Last Seen", "Type", "Reason", "Object", "Count", "Message"}
+	for col, h := range headers {
+		s.table.SetCell(0, col, tview.NewTableCell(fmt.Sprintf("[::b]%s", h)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false))
+	}
+
+	if len(s.events) == 0 {
+		s.table.SetCell(1, 0, tview.NewTableCell("No events").
+			SetTextColor(tcell.ColorGray).
+			SetSelectable(false))
+		return
+	}
+
+	now := time.Now()
+	for i, ev := range s.events {
+		color := tcell.ColorWhite
+		if ev.Type == "Warning" {
+			color = tcell.ColorOrange
+		}
+		cells := []string{
+			formatAge(now.Sub(ev.LastSeen)),
+			ev.Type,
+			ev.Reason,
+			ev.Object,
+			fmt.Sprintf("%d", ev.Count),
+			ev.Message,
+		}
+		for col, text := range cells {
+			cell := tview.NewTableCell(tview.Escape(text)).SetTextColor(color)
+			if col == len(cells)-1 {
+				cell.SetExpansion(1)
+			}
+			s.table.SetCell(i+1, col, cell)
+		}
+	}
+
+	if row < 1 {
+		row = 1
+	}
+	s.table.Select(min(row, len(s.events)), 0)
+}
+
+func (s *ScreenResourceEvents) title() string {
+	if s.ref == nil {
+		return fmt.Sprintf(" Events for application %s ", s.appName)
+	}
+	return fmt.Sprintf(" Events for %s/%s ", s.ref.Kind, s.ref.Name)
+}
+
+func (s *ScreenResourceEvents) onKey(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Rune() {
+	case 'b':
+		s.stopAutoRefresh()
+		s.router.Back()
+		return nil
+	case 'r':
+		s.refresh()
+		return nil
+	}
+	return event
+}
+
+// formatAge renders d like kubectl does: 45s, 12m, 3h, 2d.
+func formatAge(d time.Duration) string {
+	switch {
+	case d < 0:
+		return "0s"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+func (s *ScreenResourceEvents) Name() string {
+	return "ResourceEvents"
+}
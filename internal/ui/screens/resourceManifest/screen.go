@@ -0,0 +1,233 @@
+package resourceManifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Jack200062/ArguTUI/internal/transport/argocd"
+	"github.com/Jack200062/ArguTUI/internal/ui"
+	"github.com/Jack200062/ArguTUI/internal/ui/common"
+	"github.com/Jack200062/ArguTUI/internal/ui/components"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"sigs.k8s.io/yaml"
+)
+
+// ScreenResourceManifest shows the live manifest of a resource as YAML.
+type ScreenResourceManifest struct {
+	app          *tview.Application
+	instanceInfo *common.InstanceInfo
+	client       argocd.Backend
+	router       *ui.Router
+
+	appName string
+	ref     argocd.ResourceRef
+
+	grid       *tview.Grid
+	view       *tview.TextView
+	statusView *tview.TextView
+
+	// manifest is the raw JSON as returned by the server
+	manifest          string
+	showManagedFields bool
+	showStatus        bool
+}
+
+func New(
+	app *tview.Application,
+	r *ui.Router,
+	instanceInfo *common.InstanceInfo,
+	client argocd.Backend,
+	appName string,
+	ref argocd.ResourceRef,
+) *ScreenResourceManifest {
+	return &ScreenResourceManifest{
+		app:          app,
+		instanceInfo: instanceInfo,
+		client:       client,
+		router:       r,
+		appName:      appName,
+		ref:          ref,
+		showStatus:   true,
+	}
+}
+
+func (s *ScreenResourceManifest) Init() tview.Primitive {
+	textColor := tcell.NewHexColor(0x00bebe)
+	backgroundColor := tcell.NewHexColor(0x000000)
+	borderColor := tcell.NewHexColor(0x63a0bf)
+	shortcutKeyColor := tcell.NewHexColor(0x017be9)
+
+	instanceView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(s.instanceInfo.FormattedString(tcell.ColorYellow)).
+		SetTextAlign(tview.AlignLeft)
+	instanceView.SetBackgroundColor(backgroundColor)
+
+	shortcutBar := components.NewShortcutBar(backgroundColor, shortcutKeyColor)
+	shortcutBar.AddGroup("Manifest", map[string]string{
+		"m": "managedFields",
+		"x": "Status",
+		"s": "Save",
+		"r": "Reload",
+	})
+	shortcutBar.AddGroup("Navigation", map[string]string{
+		"b": "Back",
+		"q": "Quit",
+	})
+
+	topBar := tview.NewFlex().
+		SetDirection(tview.FlexColumn).
+		AddItem(instanceView, 0, 1, false).
+		AddItem(shortcutBar.Init(), 0, 2, false)
+	topBar.SetBackgroundColor(backgroundColor)
+
+	s.view = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(false).
+		SetScrollable(true)
+	s.view.SetBackgroundColor(backgroundColor)
+	s.view.SetBorder(true).
+		SetBorderColor(borderColor).
+		SetTitleColor(textColor).
+		SetTitleAlign(tview.AlignCenter)
+	s.view.SetInputCapture(s.onKey)
+
+	s.statusView = tview.NewTextView().SetDynamicColors(true)
+	s.statusView.SetBackgroundColor(backgroundColor)
+
+	s.grid = tview.NewGrid().
+		SetRows(3, 0, 1).
+		SetColumns(0).
+		SetBorders(true)
+	s.grid.AddItem(topBar, 0, 0, 1, 1, 0, 0, false).
+		AddItem(s.view, 1, 0, 1, 1, 0, 0, true).
+		AddItem(s.statusView, 2, 0, 1, 1, 0, 0, false)
+
+	if s.manifest == "" {
+		s.load()
+	} else {
+		s.render()
+	}
+	return s.grid
+}
+
+func (s *ScreenResourceManifest) load() {
+	manifest, err := s.client.GetResourceManifest(s.appName, s.ref)
+	if err != nil {
+		s.view.SetTitle(s.title())
+		s.view.SetText(fmt.Sprintf("[red]Error loading manifest: %s[-]", tview.Escape(err.Error())))
+		return
+	}
+	s.manifest = manifest
+	s.render()
+}
+
+func (s *ScreenResourceManifest) render() {
+	s.view.SetTitle(s.title())
+	text, err := s.yaml()
+	if err != nil {
+		s.view.SetText(fmt.Sprintf("[red]Error decoding manifest: %s[-]", tview.Escape(err.Error())))
+		return
+	}
+	row, col := s.view.GetScrollOffset()
+	s.view.SetText(components.HighlightYAML(text))
+	s.view.ScrollTo(row, col)
+}
+
+// yaml converts the manifest to YAML without the hidden sections.
+func (s *ScreenResourceManifest) yaml() (string, error) {
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(s.manifest), &obj); err != nil {
+		return "", err
+	}
+	if !s.showManagedFields {
+		if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+			delete(metadata, "managedFields")
+		}
+	}
+	if !s.showStatus {
+		delete(obj, "status")
+	}
+	out, err := yaml.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func (s *ScreenResourceManifest) title() string {
+	var hidden []string
+	if !s.showManagedFields {
+		hidden = append(hidden, "managedFields")
+	}
+	if !s.showStatus {
+		hidden = append(hidden, "status")
+	}
+	title := fmt.Sprintf(" Manifest: %s/%s ", s.ref.Kind, s.ref.Name)
+	if len(hidden) > 0 {
+		title += fmt.Sprintf("(hidden: %s) ", strings.Join(hidden, ", "))
+	}
+	return title
+}
+
+func (s *ScreenResourceManifest) onKey(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Rune() {
+	case 'b':
+		s.router.Back()
+		return nil
+	case 'm':
+		s.showManagedFields = !s.showManagedFields
+		s.render()
+		return nil
+	case 'x':
+		s.showStatus = !s.showStatus
+		s.render()
+		return nil
+	case 'r':
+		s.load()
+		return nil
+	case 's':
+		s.showSaveDialog()
+		return nil
+	}
+	return event
+}
+
+func (s *ScreenResourceManifest) showSaveDialog() {
+	if s.manifest == "" {
+		return
+	}
+	defaultPath := strings.ToLower(fmt.Sprintf("%s-%s.yaml", s.ref.Kind, s.ref.Name))
+	dialog := components.SaveFileModal("Save manifest", defaultPath,
+		func(path string) {
+			s.router.CloseOverlay(s.grid)
+			s.app.SetFocus(s.view)
+			s.save(path)
+		},
+		func() {
+			s.router.CloseOverlay(s.grid)
+			s.app.SetFocus(s.view)
+		},
+	)
+	s.router.ShowOverlay(dialog)
+}
+
+// save writes the manifest as currently shown, without the hidden sections.
+func (s *ScreenResourceManifest) save(path string) {
+	text, err := s.yaml()
+	if err == nil {
+		err = os.WriteFile(path, []byte(text), 0o644)
+	}
+	if err != nil {
+		s.statusView.SetText(fmt.Sprintf("[red]Error saving manifest: %s[-]", tview.Escape(err.Error())))
+		return
+	}
+	s.statusView.SetText(fmt.Sprintf("[green]Saved to %s[-]", tview.Escape(path)))
+}
+
+func (s *ScreenResourceManifest) Name() string {
+	return "ResourceManifest"
+}
@@ -0,0 +1,142 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+type Op int
+
+const (
+	Equal Op = iota
+	Insert
+	Delete
+)
+
+type Line struct {
+	Op   Op
+	Text string
+	// NoNewline is set on the last line of an input that does not end with
+	// a newline
+	NoNewline bool
+}
+
+// Lines computes a line based diff turning a into b.
+func Lines(a, b string) []Line {
+	dmp := diffmatchpatch.New()
+	chars1, chars2, lineArray := dmp.DiffLinesToChars(a, b)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(chars1, chars2, false), lineArray)
+
+	var lines []Line
+	for _, d := range diffs {
+		op := Equal
+		switch d.Type {
+		case diffmatchpatch.DiffInsert:
+			op = Insert
+		case diffmatchpatch.DiffDelete:
+			op = Delete
+		}
+		for _, text := range splitLines(d.Text) {
+			lines = append(lines, Line{Op: op, Text: text})
+		}
+		if d.Text != "" && !strings.HasSuffix(d.Text, "\n") {
+			lines[len(lines)-1].NoNewline = true
+		}
+	}
+	return lines
+}
+
+// Unified renders the diff of a and b in unified format with the given
+// number of context lines around each change. It returns an empty string
+// when both inputs are equal.
+func Unified(fromName, toName, a, b string, context int) string {
+	lines := Lines(a, b)
+
+	var out strings.Builder
+	// aLine and bLine are the 1-based positions of lines[i] in a and b
+	aLine, bLine := 1, 1
+	for i := 0; i < len(lines); {
+		if lines[i].Op == Equal {
+			i++
+			aLine++
+			bLine++
+			continue
+		}
+
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		// Extend the hunk while the next change is within 2*context lines
+		end := i
+		for end < len(lines) {
+			if lines[end].Op != Equal {
+				end++
+				continue
+			}
+			run := end
+			for run < len(lines) && lines[run].Op == Equal {
+				run++
+			}
+			if run == len(lines) || run-end > 2*context {
+				end += min(context, run-end)
+				break
+			}
+			end = run
+		}
+
+		hunkA, hunkB := aLine-(i-start), bLine-(i-start)
+		var countA, countB int
+		var body strings.Builder
+		for _, l := range lines[start:end] {
+			switch l.Op {
+			case Equal:
+				body.WriteString(" " + l.Text + "\n")
+				countA++
+				countB++
+			case Delete:
+				body.WriteString("-" + l.Text + "\n")
+				countA++
+			case Insert:
+				body.WriteString("+" + l.Text + "\n")
+				countB++
+			}
+			if l.NoNewline {
+				body.WriteString("\\ No newline at end of file\n")
+			}
+		}
+
+		// An empty range starts at the line before it, as in GNU diff
+		if countA == 0 {
+			hunkA--
+		}
+		if countB == 0 {
+			hunkB--
+		}
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		fmt.Fprintf(&out, "@@ -%d,%d +%d,%d @@\n", hunkA, countA, hunkB, countB)
+		out.WriteString(body.String())
+
+		for _, l := range lines[i:end] {
+			if l.Op != Insert {
+				aLine++
+			}
+			if l.Op != Delete {
+				bLine++
+			}
+		}
+		i = end
+	}
+	return out.String()
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
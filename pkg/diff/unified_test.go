@@ -0,0 +1,106 @@
+package diff
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// numbered returns the lines 1 to n, replacing line i with replace[i].
+func numbered(n int, replace map[int]string) string {
+	var b strings.Builder
+	for i := 1; i <= n; i++ {
+		line, ok := replace[i]
+		if !ok {
+			line = strconv.Itoa(i)
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    string
+		context int
+		want    string
+	}{
+		{
+			name:    "identical",
+			a:       "a\nb\n",
+			b:       "a\nb\n",
+			context: 3,
+			want:    "",
+		},
+		{
+			name:    "insert only",
+			a:       "a\nb\n",
+			b:       "a\nx\nb\n",
+			context: 3,
+			want:    "--- live\n+++ desired\n@@ -1,2 +1,3 @@\n a\n+x\n b\n",
+		},
+		{
+			name:    "delete only",
+			a:       "a\nb\nc\n",
+			b:       "a\nc\n",
+			context: 3,
+			want:    "--- live\n+++ desired\n@@ -1,3 +1,2 @@\n a\n-b\n c\n",
+		},
+		{
+			name:    "from empty",
+			a:       "",
+			b:       "a\n",
+			context: 3,
+			want:    "--- live\n+++ desired\n@@ -0,0 +1,1 @@\n+a\n",
+		},
+		{
+			name:    "missing trailing newline",
+			a:       "a\nb",
+			b:       "a\nb\n",
+			context: 3,
+			want:    "--- live\n+++ desired\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n",
+		},
+		{
+			name:    "changes within context share a hunk",
+			a:       numbered(10, nil),
+			b:       numbered(10, map[int]string{3: "x3", 7: "x7"}),
+			context: 2,
+			want: "--- live\n+++ desired\n@@ -1,9 +1,9 @@\n" +
+				" 1\n 2\n-3\n+x3\n 4\n 5\n 6\n-7\n+x7\n 8\n 9\n",
+		},
+		{
+			name:    "gap of twice the context still merges",
+			a:       numbered(10, nil),
+			b:       numbered(10, map[int]string{2: "x2", 7: "x7"}),
+			context: 2,
+			want: "--- live\n+++ desired\n@@ -1,9 +1,9 @@\n" +
+				" 1\n-2\n+x2\n 3\n 4\n 5\n 6\n-7\n+x7\n 8\n 9\n",
+		},
+		{
+			name:    "gap beyond twice the context splits hunks",
+			a:       numbered(10, nil),
+			b:       numbered(10, map[int]string{2: "x2", 8: "x8"}),
+			context: 2,
+			want: "--- live\n+++ desired\n" +
+				"@@ -1,4 +1,4 @@\n 1\n-2\n+x2\n 3\n 4\n" +
+				"@@ -6,5 +6,5 @@\n 6\n 7\n-8\n+x8\n 9\n 10\n",
+		},
+		{
+			name:    "no context",
+			a:       numbered(5, nil),
+			b:       numbered(5, map[int]string{3: "x3"}),
+			context: 0,
+			want:    "--- live\n+++ desired\n@@ -3,1 +3,1 @@\n-3\n+x3\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Unified("live", "desired", tt.a, tt.b, tt.context)
+			if got != tt.want {
+				t.Errorf("Unified() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}